@@ -1,11 +1,18 @@
 package main
 
 import (
+	"time"
+
 	"github.com/bil0u/galaxy-os/cmd/generators"
 	"github.com/bil0u/galaxy-os/sdk"
 	"github.com/bil0u/galaxy-os/sdk/commands"
 	"github.com/bil0u/galaxy-os/sdk/components"
+	"github.com/bil0u/galaxy-os/sdk/cooldowns"
+	"github.com/bil0u/galaxy-os/sdk/enums"
+	"github.com/bil0u/galaxy-os/sdk/guards"
 	"github.com/bil0u/galaxy-os/sdk/handlers"
+	"github.com/bil0u/galaxy-os/sdk/help"
+	"github.com/bil0u/galaxy-os/sdk/middlewares"
 	"github.com/bil0u/galaxy-os/sdk/utils"
 	"github.com/disgoorg/disgo/bot"
 	"github.com/disgoorg/disgo/cache"
@@ -30,6 +37,12 @@ func init() {
 
 	utils.RegisterGenerator(generators.RoleEnumGenerator)
 	utils.RegisterGenerator(generators.ChannelEnumGenerator)
+	utils.RegisterGenerator(generators.EmojiEnumGenerator)
+	utils.RegisterGenerator(generators.StickerEnumGenerator)
+	utils.RegisterGenerator(generators.ScheduledEventEnumGenerator)
+	utils.RegisterGenerator(generators.CommandEnumGenerator)
+	utils.RegisterGenerator(generators.PermissionMatrixMarkdownGenerator)
+	utils.RegisterGenerator(generators.PermissionMatrixCSVGenerator)
 
 	// PRODUCTION BOTS
 
@@ -48,8 +61,11 @@ func init() {
 			cache.FlagRoles,
 		},
 		Commands: []discord.ApplicationCommandCreate{
-			commands.Test,
+			commands.Test.MustBuild(),
+			commands.Help,
 			commands.Version,
+			commands.MemberInfo.Build(),
+			commands.Admin.MustBuild(),
 		},
 		CreateListeners: func(b *sdk.Bot) []bot.EventListener {
 			return []bot.EventListener{
@@ -58,11 +74,33 @@ func init() {
 		},
 		CreateRouter: func(b *sdk.Bot) *handler.Mux {
 			router := handler.New()
-			router.Command("/test", commands.TestHandler)
-			router.Autocomplete("/test", commands.TestAutocompleteHandler)
-			router.Component("/test-button", components.TestComponent)
+			router.Use(middlewares.Recover)
+			labGuards := []guards.Guard{guards.InChannel(enums.GalaxyChannels.Laboratoire)}
+			router.Group(func(r handler.Router) {
+				r.Use(guards.Require(labGuards...))
+				// Only the test command and its button are throttled, the form can always be submitted once opened
+				r.Group(func(r handler.Router) {
+					r.Use(cooldowns.Cooldown{
+						Name:        "test",
+						Limit:       cooldowns.Limit{Every: 10 * time.Second, Burst: 3},
+						Scope:       cooldowns.ScopeUser,
+						ExemptRoles: []enums.RoleEnum{enums.GalaxyRoles.Capitaine},
+					}.Middleware())
+					r.Command("/test", commands.Test.Handle)
+					components.TestButton.Register(r)
+				})
+				r.Autocomplete("/test", commands.TestAutocompleteHandler)
+				r.Component("/test-form-button", components.TestFormButton)
+				components.TestForm.MustRegister(r)
+			})
+
+			b.Help.Describe(help.Meta{Module: commands.ModuleLab, Examples: commands.TestExamples, Guards: labGuards}, "/test")
 
+			router.Command("/help", commands.CreateHelpHandler(b))
 			router.Command("/version", commands.CreateVersionHandler(b))
+			commands.MemberInfo.Register(router)
+			b.Help.Describe(help.Meta{Module: commands.ModuleGeneral}, "/help", "/version", commands.MemberInfo.Path())
+			commands.RegisterAdmin(b, router)
 			return router
 		},
 	})
@@ -81,6 +119,7 @@ func init() {
 		},
 		CreateRouter: func(b *sdk.Bot) *handler.Mux {
 			router := handler.New()
+			router.Use(middlewares.Recover)
 			router.Command("/version", commands.CreateVersionHandler(b))
 			return router
 		},
@@ -1,56 +1,43 @@
 package generators
 
 import (
-	"html/template"
-	"log"
-	"regexp"
+	"fmt"
 	"slices"
-	"strings"
-	"unicode"
 
+	"github.com/bil0u/galaxy-os/sdk"
 	"github.com/bil0u/galaxy-os/sdk/utils"
-	"github.com/disgoorg/disgo/bot"
 	"github.com/disgoorg/disgo/discord"
-	"github.com/disgoorg/snowflake/v2"
-	"golang.org/x/text/runes"
-	"golang.org/x/text/transform"
-	"golang.org/x/text/unicode/norm"
 )
 
 var ChannelEnumGenerator = &utils.SourceFileGenerator{
 	OutputFile: "sdk/enums/channel.go",
 	Header:     utils.GeneratedFileHeader,
-	TemplateFuncs: template.FuncMap{
-		"FormatChannelName": formatChannelName,
-	},
-	GetData:  getDiscordChannels,
-	Template: channelEnumTemplate,
+	GetData:    getDiscordChannels,
+	Template:   channelEnumTemplate,
 }
 
-type channelEnumGeneratorData struct {
-	CategoryChannels []discord.GuildChannel
-	Channels         []discord.GuildChannel
+type channelEnumValue struct {
+	Identifier string
+	discord.GuildChannel
 }
 
-func formatChannelName(name string) string {
-	// Remove diacritics
-	t := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
-	name, _, _ = transform.String(t, name)
-	// Remove special characters
-	return regexp.MustCompile(`[\P{L}+]`).ReplaceAllString(strings.Title(name), "")
+// channelEnumCategory is a category with its channels, in server order
+type channelEnumCategory struct {
+	channelEnumValue
+	Channels []channelEnumValue
 }
 
-func fetchGuildChannels(client bot.Client, Guilds []snowflake.ID) ([]discord.GuildChannel, error) {
-	guilds := client.Rest()
-	var channels []discord.GuildChannel
-	for _, guildID := range Guilds {
-		guildChannels, err := guilds.GetGuildChannels(guildID)
-		if err != nil {
-			return nil, err
-		}
-		channels = append(channels, guildChannels...)
-	}
-	return channels, nil
+type channelEnumGuild struct {
+	guildNamespace
+	CategoryChannels []channelEnumValue
+	Channels         []channelEnumValue
+	// Uncategorized are the channels without category, and Categories the categories with their channels
+	Uncategorized []channelEnumValue
+	Categories    []channelEnumCategory
+}
+
+type channelEnumGeneratorData struct {
+	Guilds []channelEnumGuild
 }
 
 func getParentChannel(channel discord.GuildChannel, candidates []discord.GuildChannel) *discord.GuildChannel {
@@ -62,13 +49,67 @@ func getParentChannel(channel discord.GuildChannel, candidates []discord.GuildCh
 	})
 }
 
-func getDiscordChannels(g *utils.SourceFileGenerator) any {
-	// Fetch roles from Discord
-	channels, err := fetchGuildChannels(g.Client, g.Cfg.Guilds)
+func getDiscordChannels(g *utils.SourceFileGenerator) (any, error) {
+	// Fetch guilds from the source
+	namespaces, err := fetchGuildNamespaces(g.Source, g.Cfg)
 	if err != nil {
-		log.Fatalf("Failed to fetch channels: %v", err)
+		return nil, fmt.Errorf("failed to fetch channels: %w", err)
 	}
 
+	var data channelEnumGeneratorData
+	for _, namespace := range namespaces {
+		categoryChannels, channels := sortGuildChannels(namespace.Guild.Channels)
+		namedCategories, err := nameChannels("Category", g.Cfg, categoryChannels)
+		if err != nil {
+			return nil, fmt.Errorf("failed to name the categories of guild '%s': %w", namespace.Guild.ID, err)
+		}
+		namedChannels, err := nameChannels("Channel", g.Cfg, channels)
+		if err != nil {
+			return nil, fmt.Errorf("failed to name the channels of guild '%s': %w", namespace.Guild.ID, err)
+		}
+		guild := channelEnumGuild{
+			guildNamespace:   namespace,
+			CategoryChannels: namedCategories,
+			Channels:         namedChannels,
+		}
+
+		// Channels are sorted by category, so each category keeps the order of its channels
+		for _, category := range namedCategories {
+			guild.Categories = append(guild.Categories, channelEnumCategory{channelEnumValue: category})
+		}
+		for _, channel := range namedChannels {
+			i := slices.IndexFunc(guild.Categories, func(category channelEnumCategory) bool {
+				return channel.ParentID() != nil && category.ID() == *channel.ParentID()
+			})
+			if i < 0 {
+				guild.Uncategorized = append(guild.Uncategorized, channel)
+				continue
+			}
+			guild.Categories[i].Channels = append(guild.Categories[i].Channels, channel)
+		}
+		data.Guilds = append(data.Guilds, guild)
+	}
+	return data, nil
+}
+
+func nameChannels(kind string, cfg sdk.BotConfig, channels []discord.GuildChannel) ([]channelEnumValue, error) {
+	entities := make([]namedEntity, len(channels))
+	for i, channel := range channels {
+		entities[i] = namedEntity{ID: channel.ID(), Name: channel.Name()}
+	}
+	identifiers, err := enumNamer(kind, cfg).identifiers(entities)
+	if err != nil {
+		return nil, err
+	}
+	values := make([]channelEnumValue, len(channels))
+	for i, channel := range channels {
+		values[i] = channelEnumValue{Identifier: identifiers[channel.ID()], GuildChannel: channel}
+	}
+	return values, nil
+}
+
+// sortGuildChannels splits the channels of a guild between categories and channels, both in display order
+func sortGuildChannels(channels []discord.GuildChannel) ([]discord.GuildChannel, []discord.GuildChannel) {
 	categoryChannels := utils.Filter(channels, func(channel discord.GuildChannel) bool {
 		return channel.Type() == discord.ChannelTypeGuildCategory
 	})
@@ -124,94 +165,311 @@ func getDiscordChannels(g *utils.SourceFileGenerator) any {
 		return utils.IndexOf(typePriority, c1.Type()) - utils.IndexOf(typePriority, c2.Type())
 
 	})
-	return channelEnumGeneratorData{
-		CategoryChannels: categoryChannels,
-		Channels:         channels,
-	}
+	return categoryChannels, channels
 }
 
 const channelEnumTemplate = `
 package enums
 
 import (
+	"fmt"
+	"slices"
+
+	"github.com/disgoorg/disgo/discord"
 	"github.com/disgoorg/snowflake/v2"
 )
 
-type GuildCategoryChannelEnum string
-type GuildChannelEnum string
+// GuildCategoryChannelEnum is a category of one of the guilds, identified by its ID
+type GuildCategoryChannelEnum snowflake.ID
 
-const (
+// GuildChannelEnum is a channel of one of the guilds, identified by its ID
+type GuildChannelEnum snowflake.ID
+{{ range .Guilds }}
+// {{ ident .Namespace }}Categories are the categories of the guild '{{ comment .Guild.Name }}'
+var {{ ident .Namespace }}Categories = struct {
 {{- range .CategoryChannels }}
-	GuildCategoryChannel{{ FormatChannelName .Name }} GuildCategoryChannelEnum = "{{ .Name }}"
+	{{ ident .Identifier }} GuildCategoryChannelEnum
 {{- end }}
-)
+}{
+{{- range .CategoryChannels }}
+	{{ ident .Identifier }}: {{ .ID }},
+{{- end }}
+}
 
-const (
+// {{ ident .Namespace }}Channels are the channels of the guild '{{ comment .Guild.Name }}'
+var {{ ident .Namespace }}Channels = struct {
 {{- range .Channels }}
-    GuildChannel{{ FormatChannelName .Name }} GuildChannelEnum = "{{ .Name }}"
+	{{ ident .Identifier }} GuildChannelEnum
 {{- end }}
-)
+}{
+{{- range .Channels }}
+	{{ ident .Identifier }}: {{ .ID }},
+{{- end }}
+}
+{{ end }}
+// GuildCategoryTree is a category with its channels, in server order. Channels without category are in a tree
+// whose category is 0
+type GuildCategoryTree struct {
+	Category GuildCategoryChannelEnum
+	Channels []GuildChannelEnum
+}
+
+// guildChannelTrees are the categories of every guild with their channels, in server order
+var guildChannelTrees = map[snowflake.ID][]GuildCategoryTree{
+{{- range .Guilds }}
+	{{ .Guild.ID }}: {
+		{
+			Channels: []GuildChannelEnum{
+			{{- range .Uncategorized }}
+				{{ .ID }},
+			{{- end }}
+			},
+		},
+	{{- range .Categories }}
+		{
+			Category: {{ .ID }},
+			Channels: []GuildChannelEnum{
+			{{- range .Channels }}
+				{{ .ID }},
+			{{- end }}
+			},
+		},
+	{{- end }}
+	},
+{{- end }}
+}
 
-var GuildCategoryChannelMap = map[GuildCategoryChannelEnum]snowflake.ID{
+// channelGuilds are the guilds of the categories and channels, in the order of the config
+var channelGuilds = []snowflake.ID{
+{{- range .Guilds }}
+	{{ .Guild.ID }},
+{{- end }}
+}
+
+// channelExpressions maps the Go expressions of the categories and channels, e.g. GalaxyChannels.Laboratoire, to their ID
+var channelExpressions = map[string]snowflake.ID{
+{{- range .Guilds }}
+{{- $namespace := .Namespace }}
+{{- range .CategoryChannels }}
+	{{ quote (printf "%sCategories.%s" $namespace .Identifier) }}: {{ .ID }},
+{{- end }}
+{{- range .Channels }}
+	{{ quote (printf "%sChannels.%s" $namespace .Identifier) }}: {{ .ID }},
+{{- end }}
+{{- end }}
+}
+
+// GuildCategoryChannelNames maps the categories of every guild to their name
+var GuildCategoryChannelNames = map[GuildCategoryChannelEnum]string{
+{{- range .Guilds }}
 {{- range .CategoryChannels }}
-	GuildCategoryChannel{{ FormatChannelName .Name }}: {{ .ID }},
+	{{ .ID }}: {{ quote .Name }},
+{{- end }}
 {{- end }}
 }
 
-var GuildChannelMap = map[GuildChannelEnum]snowflake.ID{
+// GuildChannelNames maps the channels of every guild to their name
+var GuildChannelNames = map[GuildChannelEnum]string{
+{{- range .Guilds }}
 {{- range .Channels }}
-    GuildChannel{{ FormatChannelName .Name }}: {{ .ID }},
+	{{ .ID }}: {{ quote .Name }},
+{{- end }}
 {{- end }}
 }
 
-var GuildChannelCategoryMap = map[GuildChannelEnum]snowflake.ID{
+// GuildChannelGuilds maps the categories and channels of every guild to their guild ID
+var GuildChannelGuilds = map[snowflake.ID]snowflake.ID{
+{{- range .Guilds }}
+{{- $guildID := .Guild.ID }}
+{{- range .CategoryChannels }}
+	{{ .ID }}: {{ $guildID }},
+{{- end }}
 {{- range .Channels }}
-	GuildChannel{{ FormatChannelName .Name }}: {{ if .ParentID }}{{ .ParentID }}{{ else }}0{{ end }},
+	{{ .ID }}: {{ $guildID }},
+{{- end }}
+{{- end }}
+}
+
+// GuildChannelCategories maps the channels of every guild to their category, channels without category are absent
+var GuildChannelCategories = map[GuildChannelEnum]GuildCategoryChannelEnum{
+{{- range .Guilds }}
+{{- range .Channels }}
+{{- if .ParentID }}
+	{{ .ID }}: {{ .ParentID }},
+{{- end }}
+{{- end }}
 {{- end }}
 }
 
 // Guild Category Channels functions
 
 func (e GuildCategoryChannelEnum) String() string {
-	return string(e)
+	return GuildCategoryChannelNames[e]
 }
 
 func (e GuildCategoryChannelEnum) ID() snowflake.ID {
-	return GuildCategoryChannelMap[e]
+	return snowflake.ID(e)
+}
+
+func (e GuildCategoryChannelEnum) GuildID() snowflake.ID {
+	return GuildChannelGuilds[e.ID()]
 }
 
 func (e GuildCategoryChannelEnum) IsValid() bool {
-	_, ok := GuildCategoryChannelMap[e]
+	_, ok := GuildCategoryChannelNames[e]
 	return ok
 }
 
+// Mention returns the mention of the category to use in messages
+func (e GuildCategoryChannelEnum) Mention() string {
+	return discord.ChannelMention(e.ID())
+}
+
+// GetChannels returns the channels of the category, in server order
 func (e GuildCategoryChannelEnum) GetChannels() []GuildChannelEnum {
-	var channels []GuildChannelEnum
-	for channel, parentID := range GuildChannelCategoryMap {
-		if parentID == e.ID() {
-			channels = append(channels, channel)
+	for _, tree := range guildChannelTrees[e.GuildID()] {
+		if tree.Category == e {
+			return slices.Clone(tree.Channels)
 		}
 	}
-	return channels
+	return nil
+}
+
+// MarshalText writes the category as its ID
+func (e GuildCategoryChannelEnum) MarshalText() ([]byte, error) {
+	return []byte(e.ID().String()), nil
+}
+
+// UnmarshalText reads a category from its ID or its Go expression, e.g. GalaxyCategories.QuartiersSecurises
+func (e *GuildCategoryChannelEnum) UnmarshalText(text []byte) error {
+	id, err := parseChannel(text)
+	if err != nil || !GuildCategoryChannelEnum(id).IsValid() {
+		return fmt.Errorf("unknown category '%s'", text)
+	}
+	*e = GuildCategoryChannelEnum(id)
+	return nil
+}
+
+// AllGuildCategoryChannels returns the categories of every guild, guild by guild, in server order
+func AllGuildCategoryChannels() []GuildCategoryChannelEnum {
+	var categories []GuildCategoryChannelEnum
+	for _, guildID := range channelGuilds {
+		for _, tree := range guildChannelTrees[guildID] {
+			if tree.Category != 0 {
+				categories = append(categories, tree.Category)
+			}
+		}
+	}
+	return categories
+}
+
+// GuildCategoryChannelFromID returns the category having the ID, or false if there is none
+func GuildCategoryChannelFromID(id snowflake.ID) (GuildCategoryChannelEnum, bool) {
+	return GuildCategoryChannelEnum(id), GuildCategoryChannelEnum(id).IsValid()
+}
+
+// GuildCategoryChannelFromName returns the first category of the guild having the name, or false if there is none
+func GuildCategoryChannelFromName(guildID snowflake.ID, name string) (GuildCategoryChannelEnum, bool) {
+	for _, tree := range guildChannelTrees[guildID] {
+		if tree.Category != 0 && tree.Category.String() == name {
+			return tree.Category, true
+		}
+	}
+	return 0, false
 }
 
 // Guild Channels functions
 
 func (e GuildChannelEnum) String() string {
-	return string(e)
+	return GuildChannelNames[e]
 }
 
 func (e GuildChannelEnum) ID() snowflake.ID {
-	return GuildChannelMap[e]
+	return snowflake.ID(e)
+}
+
+func (e GuildChannelEnum) GuildID() snowflake.ID {
+	return GuildChannelGuilds[e.ID()]
 }
 
 func (e GuildChannelEnum) IsValid() bool {
-	_, ok := GuildChannelMap[e]
+	_, ok := GuildChannelNames[e]
 	return ok
 }
 
+// ParentID returns the ID of the category of the channel, or 0 if it has none
 func (e GuildChannelEnum) ParentID() snowflake.ID {
-	return GuildChannelCategoryMap[e]
+	return GuildChannelCategories[e].ID()
+}
+
+// Category returns the category of the channel, or 0 if it has none
+func (e GuildChannelEnum) Category() GuildCategoryChannelEnum {
+	return GuildChannelCategories[e]
+}
+
+// Mention returns the mention of the channel to use in messages
+func (e GuildChannelEnum) Mention() string {
+	return discord.ChannelMention(e.ID())
+}
+
+// MarshalText writes the channel as its ID
+func (e GuildChannelEnum) MarshalText() ([]byte, error) {
+	return []byte(e.ID().String()), nil
+}
+
+// UnmarshalText reads a channel from its ID or its Go expression, e.g. GalaxyChannels.Laboratoire
+func (e *GuildChannelEnum) UnmarshalText(text []byte) error {
+	id, err := parseChannel(text)
+	if err != nil || !GuildChannelEnum(id).IsValid() {
+		return fmt.Errorf("unknown channel '%s'", text)
+	}
+	*e = GuildChannelEnum(id)
+	return nil
+}
+
+// AllGuildChannels returns the channels of every guild, guild by guild, in server order
+func AllGuildChannels() []GuildChannelEnum {
+	var channels []GuildChannelEnum
+	for _, guildID := range channelGuilds {
+		for _, tree := range guildChannelTrees[guildID] {
+			channels = append(channels, tree.Channels...)
+		}
+	}
+	return channels
+}
+
+// GuildChannelFromID returns the channel having the ID, or false if there is none
+func GuildChannelFromID(id snowflake.ID) (GuildChannelEnum, bool) {
+	return GuildChannelEnum(id), GuildChannelEnum(id).IsValid()
+}
+
+// GuildChannelFromName returns the first channel of the guild having the name, in server order, or false if there is none
+func GuildChannelFromName(guildID snowflake.ID, name string) (GuildChannelEnum, bool) {
+	for _, tree := range guildChannelTrees[guildID] {
+		for _, channel := range tree.Channels {
+			if channel.String() == name {
+				return channel, true
+			}
+		}
+	}
+	return 0, false
 }
 
+// GuildChannelTree returns the categories of the guild with their channels, in server order.
+// The channels without category come first, in a tree whose category is 0
+func GuildChannelTree(guildID snowflake.ID) []GuildCategoryTree {
+	trees := slices.Clone(guildChannelTrees[guildID])
+	for i := range trees {
+		trees[i].Channels = slices.Clone(trees[i].Channels)
+	}
+	return trees
+}
+
+// parseChannel reads the ID of a category or channel from its ID or its Go expression
+func parseChannel(text []byte) (snowflake.ID, error) {
+	if id, ok := channelExpressions[string(text)]; ok {
+		return id, nil
+	}
+	return snowflake.Parse(string(text))
+}
 `
@@ -0,0 +1,229 @@
+package generators
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+
+	"github.com/bil0u/galaxy-os/sdk"
+	"github.com/bil0u/galaxy-os/sdk/snapshot"
+	"github.com/bil0u/galaxy-os/sdk/utils"
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/snowflake/v2"
+)
+
+var CommandEnumGenerator = &utils.SourceFileGenerator{
+	OutputFile: "sdk/enums/command.go",
+	Header:     utils.GeneratedFileHeader,
+	GetData:    getDiscordCommands,
+	Template:   commandEnumTemplate,
+}
+
+type commandEnumValue struct {
+	Identifier string
+	ID         snowflake.ID
+	Name       string
+}
+
+// commandEnumScope are the commands of a bot, either global or registered in a guild
+type commandEnumScope struct {
+	Namespace string
+	Bot       string
+	GuildID   snowflake.ID
+	GuildName string
+	Commands  []commandEnumValue
+}
+
+type commandEnumGeneratorData struct {
+	Scopes []commandEnumScope
+}
+
+func getDiscordCommands(g *utils.SourceFileGenerator) (any, error) {
+	var data commandEnumGeneratorData
+	allParts := sdk.GetAllBotParts()
+	for _, botName := range slices.Sorted(maps.Keys(allParts)) {
+		parts := allParts[botName]
+		if len(parts.Commands) == 0 {
+			continue
+		}
+
+		// Fetch the commands registered by the bot, bots without config are skipped
+		commands, err := g.Source.Commands(botName)
+		if errors.Is(err, snapshot.ErrNoCommands) {
+			slog.Warn(fmt.Sprintf("Skipping commands of bot '%s'", botName), slog.Any("err", err))
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch commands: %w", err)
+		}
+		reportMissingCommands(parts.Commands, commands)
+
+		scopes, err := nameCommandScopes(g.Cfg, commands)
+		if err != nil {
+			return nil, fmt.Errorf("failed to name the commands of bot '%s': %w", botName, err)
+		}
+		data.Scopes = append(data.Scopes, scopes...)
+	}
+	return data, nil
+}
+
+// reportMissingCommands warns about the commands of a bot that are not registered where the bot syncs them
+func reportMissingCommands(local []discord.ApplicationCommandCreate, commands *snapshot.Commands) {
+	report := func(remote []discord.ApplicationCommand, where string) {
+		for _, command := range local {
+			registered := slices.ContainsFunc(remote, func(c discord.ApplicationCommand) bool {
+				return c.Type() == command.Type() && c.Name() == command.CommandName()
+			})
+			if !registered {
+				slog.Warn(fmt.Sprintf("Command '%s' of bot '%s' is not registered %s, sync the commands to register it", command.CommandName(), commands.Bot, where))
+			}
+		}
+	}
+	if len(commands.Guilds) == 0 {
+		report(commands.Global, "globally")
+	}
+	for _, guild := range commands.Guilds {
+		report(guild.Commands, fmt.Sprintf("in guild '%s'", guild.Name))
+	}
+}
+
+// nameCommandScopes names the slash commands of a bot, global commands are in the <Bot>Commands namespace,
+// and the ones of a guild in the <Bot><Guild>Commands namespace
+func nameCommandScopes(cfg sdk.BotConfig, commands *snapshot.Commands) ([]commandEnumScope, error) {
+	botNamespace := toIdentifier(commands.Bot)
+	if !isValidIdentifier(botNamespace) {
+		return nil, fmt.Errorf("bot name '%s' makes the invalid Go identifier '%s'", commands.Bot, botNamespace)
+	}
+
+	var guildEntities []namedEntity
+	for _, guild := range commands.Guilds {
+		guildEntities = append(guildEntities, guildEntity(cfg, guild.ID, guild.Name))
+	}
+	guildNames, err := guildNamer()
+	if err != nil {
+		return nil, err
+	}
+	guildIdentifiers, err := guildNames.identifiers(guildEntities)
+	if err != nil {
+		return nil, err
+	}
+
+	// Bots syncing their commands to guilds have no global namespace, unless they still have global commands
+	var scopes []commandEnumScope
+	if len(commands.Global) > 0 || len(commands.Guilds) == 0 {
+		global, err := nameCommands(cfg, commands.Global)
+		if err != nil {
+			return nil, err
+		}
+		scopes = append(scopes, commandEnumScope{Namespace: botNamespace, Bot: commands.Bot, Commands: global})
+	}
+	for _, guild := range commands.Guilds {
+		values, err := nameCommands(cfg, guild.Commands)
+		if err != nil {
+			return nil, fmt.Errorf("failed to name the commands of guild '%s': %w", guild.ID, err)
+		}
+		scopes = append(scopes, commandEnumScope{
+			Namespace: botNamespace + guildIdentifiers[guild.ID],
+			Bot:       commands.Bot,
+			GuildID:   guild.ID,
+			GuildName: guild.Name,
+			Commands:  values,
+		})
+	}
+	return scopes, nil
+}
+
+// nameCommands names the slash commands, the only ones that can be mentioned
+func nameCommands(cfg sdk.BotConfig, commands []discord.ApplicationCommand) ([]commandEnumValue, error) {
+	slashCommands := utils.Filter(commands, func(command discord.ApplicationCommand) bool {
+		return command.Type() == discord.ApplicationCommandTypeSlash
+	})
+	entities := make([]namedEntity, len(slashCommands))
+	for i, command := range slashCommands {
+		entities[i] = namedEntity{ID: command.ID(), Name: command.Name()}
+	}
+	identifiers, err := enumNamer("Command", cfg).identifiers(entities)
+	if err != nil {
+		return nil, err
+	}
+	values := make([]commandEnumValue, len(slashCommands))
+	for i, command := range slashCommands {
+		values[i] = commandEnumValue{Identifier: identifiers[command.ID()], ID: command.ID(), Name: command.Name()}
+	}
+	return values, nil
+}
+
+const commandEnumTemplate = `
+package enums
+
+import (
+	"strings"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/snowflake/v2"
+)
+
+// CommandEnum is a slash command registered by one of the bots, identified by its ID
+type CommandEnum snowflake.ID
+{{ range .Scopes }}
+{{- if .GuildID }}
+// {{ ident .Namespace }}Commands are the slash commands of the bot '{{ comment .Bot }}' in the guild '{{ comment .GuildName }}'
+{{- else }}
+// {{ ident .Namespace }}Commands are the global slash commands of the bot '{{ comment .Bot }}'
+{{- end }}
+var {{ ident .Namespace }}Commands = struct {
+{{- range .Commands }}
+	{{ ident .Identifier }} CommandEnum
+{{- end }}
+}{
+{{- range .Commands }}
+	{{ ident .Identifier }}: {{ .ID }},
+{{- end }}
+}
+{{ end }}
+// CommandNames maps the slash commands of every bot to their name
+var CommandNames = map[CommandEnum]string{
+{{- range .Scopes }}
+{{- range .Commands }}
+	{{ .ID }}: {{ quote .Name }},
+{{- end }}
+{{- end }}
+}
+
+// CommandGuilds maps the guild slash commands of every bot to their guild ID, global commands have none
+var CommandGuilds = map[CommandEnum]snowflake.ID{
+{{- range .Scopes }}
+{{- $guildID := .GuildID }}
+{{- if $guildID }}
+{{- range .Commands }}
+	{{ .ID }}: {{ $guildID }},
+{{- end }}
+{{- end }}
+{{- end }}
+}
+
+func (e CommandEnum) String() string {
+	return CommandNames[e]
+}
+
+func (e CommandEnum) ID() snowflake.ID {
+	return snowflake.ID(e)
+}
+
+func (e CommandEnum) GuildID() snowflake.ID {
+	return CommandGuilds[e]
+}
+
+func (e CommandEnum) IsValid() bool {
+	_, ok := CommandNames[e]
+	return ok
+}
+
+// Mention returns the clickable mention of the command, or of one of its subcommands,
+// e.g. Mention("commands", "sync") mentions /admin commands sync
+func (e CommandEnum) Mention(subcommand ...string) string {
+	return discord.SlashCommandMention(e.ID(), strings.Join(append([]string{e.String()}, subcommand...), " "))
+}
+`
@@ -0,0 +1,84 @@
+package generators
+
+import (
+	"bytes"
+	"log/slog"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/bil0u/galaxy-os/sdk/snapshot"
+	"github.com/disgoorg/disgo/discord"
+)
+
+// captureWarnings returns the messages logged while running f
+func captureWarnings(f func()) []string {
+	var buf bytes.Buffer
+	previous := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{
+		ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
+			if a.Key != slog.MessageKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	})))
+	defer slog.SetDefault(previous)
+	f()
+
+	var messages []string
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line != "" {
+			messages = append(messages, strings.Trim(strings.TrimPrefix(line, "msg="), `"`))
+		}
+	}
+	return messages
+}
+
+func TestReportMissingCommands(t *testing.T) {
+	synced, err := snapshot.NewDirSource("testdata").Commands("hue")
+	if err != nil {
+		t.Fatal(err)
+	}
+	global := &snapshot.Commands{Bot: "hue", Global: synced.Global}
+
+	tests := []struct {
+		name     string
+		local    []discord.ApplicationCommandCreate
+		commands *snapshot.Commands
+		want     []string
+	}{
+		{
+			name:     "synced to guilds",
+			local:    testCommands,
+			commands: synced,
+			want:     []string{"Command 'help' of bot 'hue' is not registered in guild 'Test Guild', sync the commands to register it"},
+		},
+		{
+			// Without guilds, the commands are expected to be registered globally
+			name:     "global",
+			local:    []discord.ApplicationCommandCreate{discord.SlashCommandCreate{Name: "version"}, discord.SlashCommandCreate{Name: "admin"}},
+			commands: global,
+			want:     []string{"Command 'admin' of bot 'hue' is not registered globally, sync the commands to register it"},
+		},
+		{
+			name:     "same name with another type",
+			local:    []discord.ApplicationCommandCreate{discord.UserCommandCreate{Name: "version"}},
+			commands: global,
+			want:     []string{"Command 'version' of bot 'hue' is not registered globally, sync the commands to register it"},
+		},
+		{
+			name:     "all registered",
+			local:    []discord.ApplicationCommandCreate{discord.SlashCommandCreate{Name: "test"}, discord.UserCommandCreate{Name: "Show member info"}},
+			commands: synced,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := captureWarnings(func() { reportMissingCommands(tt.local, tt.commands) })
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("warnings = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package generators
+
+import (
+	"fmt"
+
+	"github.com/bil0u/galaxy-os/sdk/utils"
+	"github.com/disgoorg/disgo/discord"
+)
+
+var EmojiEnumGenerator = &utils.SourceFileGenerator{
+	OutputFile: "sdk/enums/emoji.go",
+	Header:     utils.GeneratedFileHeader,
+	GetData:    getDiscordEmojis,
+	Template:   emojiEnumTemplate,
+}
+
+type emojiEnumValue struct {
+	Identifier string
+	discord.Emoji
+}
+
+type emojiEnumGuild struct {
+	guildNamespace
+	Emojis []emojiEnumValue
+}
+
+type emojiEnumGeneratorData struct {
+	Guilds []emojiEnumGuild
+}
+
+func getDiscordEmojis(g *utils.SourceFileGenerator) (any, error) {
+	// Fetch guilds from the source
+	namespaces, err := fetchGuildNamespaces(g.Source, g.Cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch emojis: %w", err)
+	}
+
+	var data emojiEnumGeneratorData
+	for _, namespace := range namespaces {
+		// Emojis are sorted by ID in snapshots, the oldest first
+		emojis := namespace.Guild.Emojis
+
+		// Naming them
+		entities := make([]namedEntity, len(emojis))
+		for i, emoji := range emojis {
+			entities[i] = namedEntity{ID: emoji.ID, Name: emoji.Name}
+		}
+		identifiers, err := enumNamer("Emoji", g.Cfg).identifiers(entities)
+		if err != nil {
+			return nil, fmt.Errorf("failed to name the emojis of guild '%s': %w", namespace.Guild.ID, err)
+		}
+
+		guild := emojiEnumGuild{guildNamespace: namespace}
+		for _, emoji := range emojis {
+			guild.Emojis = append(guild.Emojis, emojiEnumValue{Identifier: identifiers[emoji.ID], Emoji: emoji})
+		}
+		data.Guilds = append(data.Guilds, guild)
+	}
+	return data, nil
+}
+
+const emojiEnumTemplate = `
+package enums
+
+import (
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/snowflake/v2"
+)
+
+// EmojiEnum is a custom emoji of one of the guilds, identified by its ID
+type EmojiEnum snowflake.ID
+{{ range .Guilds }}
+// {{ ident .Namespace }}Emojis are the custom emojis of the guild '{{ comment .Guild.Name }}'
+var {{ ident .Namespace }}Emojis = struct {
+{{- range .Emojis }}
+	{{ ident .Identifier }} EmojiEnum
+{{- end }}
+}{
+{{- range .Emojis }}
+	{{ ident .Identifier }}: {{ .ID }},
+{{- end }}
+}
+{{ end }}
+// EmojiNames maps the custom emojis of every guild to their name
+var EmojiNames = map[EmojiEnum]string{
+{{- range .Guilds }}
+{{- range .Emojis }}
+	{{ .ID }}: {{ quote .Name }},
+{{- end }}
+{{- end }}
+}
+
+// EmojiGuilds maps the custom emojis of every guild to their guild ID
+var EmojiGuilds = map[EmojiEnum]snowflake.ID{
+{{- range .Guilds }}
+{{- $guildID := .Guild.ID }}
+{{- range .Emojis }}
+	{{ .ID }}: {{ $guildID }},
+{{- end }}
+{{- end }}
+}
+
+// AnimatedEmojis are the animated custom emojis of every guild
+var AnimatedEmojis = map[EmojiEnum]bool{
+{{- range .Guilds }}
+{{- range .Emojis }}
+{{- if .Animated }}
+	{{ .ID }}: true,
+{{- end }}
+{{- end }}
+{{- end }}
+}
+
+func (e EmojiEnum) String() string {
+	return EmojiNames[e]
+}
+
+func (e EmojiEnum) ID() snowflake.ID {
+	return snowflake.ID(e)
+}
+
+func (e EmojiEnum) GuildID() snowflake.ID {
+	return EmojiGuilds[e]
+}
+
+func (e EmojiEnum) IsValid() bool {
+	_, ok := EmojiNames[e]
+	return ok
+}
+
+func (e EmojiEnum) IsAnimated() bool {
+	return AnimatedEmojis[e]
+}
+
+// Mention returns the emoji as it is written in messages, e.g. <:name:id>, or <a:name:id> if it is animated
+func (e EmojiEnum) Mention() string {
+	if e.IsAnimated() {
+		return discord.AnimatedEmojiMention(e.ID(), e.String())
+	}
+	return discord.EmojiMention(e.ID(), e.String())
+}
+
+// Emoji returns the emoji to use in components and reactions
+func (e EmojiEnum) Emoji() discord.ComponentEmoji {
+	return discord.ComponentEmoji{ID: e.ID(), Name: e.String(), Animated: e.IsAnimated()}
+}
+`
@@ -0,0 +1,78 @@
+package generators
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/bil0u/galaxy-os/sdk"
+	"github.com/bil0u/galaxy-os/sdk/snapshot"
+	"github.com/bil0u/galaxy-os/sdk/utils"
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/snowflake/v2"
+)
+
+var update = flag.Bool("update", false, "update the golden files of the generators")
+
+// testGuildID is the guild of the snapshot in testdata
+const testGuildID snowflake.ID = 100000000000000001
+
+// testCommands are the commands of the bot of the commands snapshot in testdata, /help was never synced
+var testCommands = []discord.ApplicationCommandCreate{
+	discord.SlashCommandCreate{Name: "admin"},
+	discord.SlashCommandCreate{Name: "test"},
+	discord.SlashCommandCreate{Name: "version"},
+	discord.SlashCommandCreate{Name: "help"},
+	discord.UserCommandCreate{Name: "Show member info"},
+}
+
+// registerTestBot registers the bot of the commands snapshot, the command enums are generated for the registered bots
+var registerTestBot = sync.OnceValue(func() error {
+	return sdk.RegisterBotParts("hue", sdk.BotParts{Commands: testCommands})
+})
+
+func testConfig() sdk.BotConfig {
+	return sdk.BotConfig{
+		Guilds:       []snowflake.ID{testGuildID},
+		GuildAliases: map[snowflake.ID]string{testGuildID: "test"},
+		EnumNames:    map[snowflake.ID]string{100000000000000016: "Guest"},
+	}
+}
+
+// TestGenerators renders the files of the testdata snapshot and compares them with the golden files,
+// run the tests with -update to write the golden files again
+func TestGenerators(t *testing.T) {
+	if err := registerTestBot(); err != nil {
+		t.Fatal(err)
+	}
+	generators := []utils.FileRenderer{
+		RoleEnumGenerator, ChannelEnumGenerator, EmojiEnumGenerator, StickerEnumGenerator, ScheduledEventEnumGenerator, CommandEnumGenerator,
+		PermissionMatrixMarkdownGenerator, PermissionMatrixCSVGenerator,
+	}
+	for _, g := range generators {
+		t.Run(g.Name(), func(t *testing.T) {
+			g.Setup(snapshot.NewDirSource("testdata"), testConfig())
+			rendered, err := g.Render()
+			if err != nil {
+				t.Fatalf("failed to render: %v", err)
+			}
+
+			golden := filepath.Join("testdata", g.Name()+".golden")
+			if *update {
+				if err := os.WriteFile(golden, rendered, 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			expected, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(rendered, expected) {
+				t.Errorf("rendered file differs from %s:\n%s", golden, utils.UnifiedDiff(golden, g.Name(), expected, rendered))
+			}
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package generators
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"slices"
+	"sync"
+
+	"github.com/bil0u/galaxy-os/sdk"
+	"github.com/bil0u/galaxy-os/sdk/snapshot"
+	"github.com/bil0u/galaxy-os/sdk/utils"
+	"github.com/disgoorg/snowflake/v2"
+)
+
+// enumTemplates are the templates of the enums package, with their data when there are no guilds
+var enumTemplates = []struct {
+	file     string
+	template string
+	empty    any
+}{
+	{"role.go", roleEnumTemplate, roleEnumGeneratorData{}},
+	{"channel.go", channelEnumTemplate, channelEnumGeneratorData{}},
+	{"emoji.go", emojiEnumTemplate, emojiEnumGeneratorData{}},
+	{"sticker.go", stickerEnumTemplate, stickerEnumGeneratorData{}},
+	{"scheduled_event.go", scheduledEventEnumTemplate, scheduledEventEnumGeneratorData{}},
+	{"command.go", commandEnumTemplate, commandEnumGeneratorData{}},
+}
+
+// packageIdentifiers returns the identifiers the generators declare in the enums package, besides the namespaces.
+// They are read from the templates rendered without guilds, so that they follow the templates
+var packageIdentifiers = sync.OnceValues(func() ([]string, error) {
+	var identifiers []string
+	for _, t := range enumTemplates {
+		g := &utils.SourceFileGenerator{
+			OutputFile: t.file,
+			Header:     utils.GeneratedFileHeader,
+			Template:   t.template,
+			GetData:    func(*utils.SourceFileGenerator) (any, error) { return t.empty, nil },
+		}
+		source, err := g.Render()
+		if err != nil {
+			return nil, fmt.Errorf("failed to render template of '%s': %w", t.file, err)
+		}
+		file, err := parser.ParseFile(token.NewFileSet(), t.file, source, 0)
+		if err != nil {
+			return nil, err
+		}
+		for _, decl := range file.Decls {
+			switch decl := decl.(type) {
+			case *ast.FuncDecl:
+				// Methods are not declared in the package scope
+				if decl.Recv == nil {
+					identifiers = append(identifiers, decl.Name.Name)
+				}
+			case *ast.GenDecl:
+				for _, spec := range decl.Specs {
+					switch spec := spec.(type) {
+					case *ast.TypeSpec:
+						identifiers = append(identifiers, spec.Name.Name)
+					case *ast.ValueSpec:
+						for _, name := range spec.Names {
+							identifiers = append(identifiers, name.Name)
+						}
+					}
+				}
+			}
+		}
+	}
+	return identifiers, nil
+})
+
+// namespaceSuffixes end the names of the namespaces of a guild, e.g. GalaxyRoles
+var namespaceSuffixes = []string{"Roles", "Categories", "Channels", "Emojis", "Stickers", "ScheduledEvents"}
+
+// enumNamer names the entities of a guild in the generated enums, names can be overridden in the config
+func enumNamer(kind string, cfg sdk.BotConfig) namer {
+	return namer{Kind: kind, Overrides: cfg.EnumNames, Setting: "enum_names"}
+}
+
+// guildNamespace is a guild whose enums are prefixed by Namespace, e.g. GalaxyRoles
+type guildNamespace struct {
+	Namespace string
+	Guild     *snapshot.Guild
+}
+
+// guildNamer names the guilds, a guild namespace can't make the name of another identifier
+// of the package, e.g. GuildChannel + Categories
+func guildNamer() (namer, error) {
+	reserved, err := packageIdentifiers()
+	if err != nil {
+		return namer{}, err
+	}
+	return namer{
+		Kind:    "Guild",
+		Setting: "guild_aliases",
+		Reserved: func(namespace string) bool {
+			return slices.ContainsFunc(namespaceSuffixes, func(suffix string) bool {
+				return slices.Contains(reserved, namespace+suffix)
+			})
+		},
+	}, nil
+}
+
+// guildEntity returns the guild to name, named after its alias in the config if it has one
+func guildEntity(cfg sdk.BotConfig, guildID snowflake.ID, name string) namedEntity {
+	if alias := cfg.GetGuildAlias(guildID); alias != "" {
+		name = alias
+	}
+	return namedEntity{ID: guildID, Name: name}
+}
+
+// fetchGuildNamespaces reads the guilds of the config, each one is named after its alias
+// in the config, or after its name if it has none
+func fetchGuildNamespaces(source snapshot.Source, cfg sdk.BotConfig) ([]guildNamespace, error) {
+	var guilds []*snapshot.Guild
+	var entities []namedEntity
+	for _, guildID := range cfg.Guilds {
+		guild, err := source.Guild(guildID)
+		if err != nil {
+			return nil, err
+		}
+		guilds = append(guilds, guild)
+		entities = append(entities, guildEntity(cfg, guildID, guild.Name))
+	}
+
+	guildNames, err := guildNamer()
+	if err != nil {
+		return nil, err
+	}
+	identifiers, err := guildNames.identifiers(entities)
+	if err != nil {
+		return nil, err
+	}
+
+	namespaces := make([]guildNamespace, len(guilds))
+	for i, guild := range guilds {
+		namespaces[i] = guildNamespace{Namespace: identifiers[guild.ID], Guild: guild}
+	}
+	return namespaces, nil
+}
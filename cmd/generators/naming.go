@@ -0,0 +1,114 @@
+package generators
+
+import (
+	"cmp"
+	"fmt"
+	"go/token"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/bil0u/galaxy-os/sdk/utils"
+	"github.com/disgoorg/snowflake/v2"
+)
+
+// apostrophes join the words they separate, e.g. "d’équipage" becomes "Dequipage"
+const apostrophes = "'’ʼ"
+
+// toIdentifier turns a name into a Go identifier: latin letters are transliterated, each word
+// is capitalized, and everything but letters and digits is dropped. It returns an empty string
+// if the name has neither letters nor digits, e.g. if it is made of emojis only
+func toIdentifier(name string) string {
+	var b strings.Builder
+	newWord := true
+	for _, r := range utils.Transliterate(name) {
+		switch {
+		case strings.ContainsRune(apostrophes, r):
+			continue
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			if newWord {
+				r = unicode.ToTitle(r)
+			}
+			b.WriteRune(r)
+			newWord = false
+		default:
+			newWord = true
+		}
+	}
+	return b.String()
+}
+
+// isValidIdentifier returns true if s can name an exported field or variable
+func isValidIdentifier(s string) bool {
+	return token.IsIdentifier(s) && token.IsExported(s)
+}
+
+// namedEntity is a discord entity to name in the generated code
+type namedEntity struct {
+	ID   snowflake.ID
+	Name string
+}
+
+// namer gives distinct identifiers to discord entities, the same entities always get the same identifiers
+type namer struct {
+	// Kind names the entities in errors, and starts the identifiers of names that can't start one,
+	// e.g. a channel named "2024" is named Channel2024
+	Kind string
+	// Overrides are identifiers set in the config, by ID
+	Overrides map[snowflake.ID]string
+	// Reserved returns true for identifiers the generated code can't use, it is optional
+	Reserved func(identifier string) bool
+	// Setting is the config setting to use to solve naming errors
+	Setting string
+}
+
+// identifiers returns the identifier of each entity, by ID. Entities whose names would collide
+// are told apart by their ID: the oldest entity keeps the identifier, the others get their ID as suffix
+func (n namer) identifiers(entities []namedEntity) (map[snowflake.ID]string, error) {
+	identifiers := make(map[snowflake.ID]string, len(entities))
+	groups := map[string][]namedEntity{}
+	for _, entity := range entities {
+		if override, ok := n.Overrides[entity.ID]; ok {
+			if !isValidIdentifier(override) {
+				return nil, fmt.Errorf("name '%s' set in %s for %s '%s' (%s) is not an exported Go identifier", override, n.Setting, strings.ToLower(n.Kind), entity.Name, entity.ID)
+			}
+			identifiers[entity.ID] = override
+			continue
+		}
+
+		identifier := toIdentifier(entity.Name)
+		switch {
+		case identifier == "":
+			identifier = n.Kind + entity.ID.String()
+		case !token.IsExported(identifier):
+			// Names starting with a digit or with a letter without case
+			identifier = n.Kind + identifier
+		}
+		if n.Reserved != nil && n.Reserved(identifier) {
+			identifier += "_" + entity.ID.String()
+		}
+		groups[identifier] = append(groups[identifier], entity)
+	}
+
+	for identifier, group := range groups {
+		slices.SortFunc(group, func(a, b namedEntity) int { return cmp.Compare(a.ID, b.ID) })
+		identifiers[group[0].ID] = identifier
+		for _, entity := range group[1:] {
+			identifiers[entity.ID] = identifier + "_" + entity.ID.String()
+		}
+	}
+
+	// Overrides may still collide with other identifiers, and some names may not make valid identifiers
+	seen := map[string]namedEntity{}
+	for _, entity := range entities {
+		identifier := identifiers[entity.ID]
+		if !isValidIdentifier(identifier) {
+			return nil, fmt.Errorf("%s '%s' (%s) makes the invalid Go identifier '%s', set a name for it in %s", strings.ToLower(n.Kind), entity.Name, entity.ID, identifier, n.Setting)
+		}
+		if other, ok := seen[identifier]; ok {
+			return nil, fmt.Errorf("%s names '%s' (%s) and '%s' (%s) both make '%s', set distinct names for them in %s", strings.ToLower(n.Kind), other.Name, other.ID, entity.Name, entity.ID, identifier, n.Setting)
+		}
+		seen[identifier] = entity
+	}
+	return identifiers, nil
+}
@@ -0,0 +1,144 @@
+package generators
+
+import (
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/disgoorg/snowflake/v2"
+)
+
+func TestToIdentifier(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"general", "General"},
+		{"salon général", "SalonGeneral"},
+		{"d’équipage", "Dequipage"},
+		{"l'équipe", "Lequipe"},
+		{"hello-world_2", "HelloWorld2"},
+		{"2024", "2024"},
+		{"🚀✨", ""},
+		{"🚀 launch", "Launch"},
+	}
+	for _, tt := range tests {
+		if got := toIdentifier(tt.name); got != tt.want {
+			t.Errorf("toIdentifier('%s') = '%s', want '%s'", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestNamerIdentifiers(t *testing.T) {
+	n := namer{Kind: "Channel", Setting: "enum_names"}
+	tests := []struct {
+		name     string
+		entities []namedEntity
+		want     map[snowflake.ID]string
+	}{
+		{
+			name:     "emoji only",
+			entities: []namedEntity{{ID: 10, Name: "🚀✨"}},
+			want:     map[snowflake.ID]string{10: "Channel10"},
+		},
+		{
+			name:     "leading digit",
+			entities: []namedEntity{{ID: 10, Name: "2024"}},
+			want:     map[snowflake.ID]string{10: "Channel2024"},
+		},
+		{
+			name:     "apostrophe",
+			entities: []namedEntity{{ID: 10, Name: "d’équipage"}},
+			want:     map[snowflake.ID]string{10: "Dequipage"},
+		},
+		{
+			name:     "collision",
+			entities: []namedEntity{{ID: 20, Name: "général"}, {ID: 10, Name: "General"}},
+			want:     map[snowflake.ID]string{10: "General", 20: "General_20"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := n.identifiers(tt.entities)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !maps.Equal(got, tt.want) {
+				t.Errorf("identifiers = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestNamerStableSuffixes makes sure entities keep their identifiers when newer entities get the same name
+func TestNamerStableSuffixes(t *testing.T) {
+	n := namer{Kind: "Channel", Setting: "enum_names"}
+	entities := []namedEntity{{ID: 10, Name: "general"}, {ID: 20, Name: "General"}}
+	before, err := n.identifiers(entities)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entities = append([]namedEntity{{ID: 30, Name: "général"}}, entities...)
+	after, err := n.identifiers(entities)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for id, identifier := range before {
+		if after[id] != identifier {
+			t.Errorf("identifier of %s changed from '%s' to '%s'", id, identifier, after[id])
+		}
+	}
+	if after[30] != "General_30" {
+		t.Errorf("identifier of the newest entity = '%s', want 'General_30'", after[30])
+	}
+
+	slices.Reverse(entities)
+	reversed, err := n.identifiers(entities)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !maps.Equal(after, reversed) {
+		t.Errorf("identifiers depend on the order of the entities: %v, then %v", after, reversed)
+	}
+}
+
+func TestNamerOverrides(t *testing.T) {
+	entities := []namedEntity{{ID: 10, Name: "general"}, {ID: 20, Name: "annonces"}}
+
+	n := namer{Kind: "Channel", Setting: "enum_names", Overrides: map[snowflake.ID]string{20: "News"}}
+	got, err := n.identifiers(entities)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := map[snowflake.ID]string{10: "General", 20: "News"}; !maps.Equal(got, want) {
+		t.Errorf("identifiers = %v, want %v", got, want)
+	}
+
+	// An override can't take the identifier generated for another entity
+	n.Overrides = map[snowflake.ID]string{20: "General"}
+	if _, err := n.identifiers(entities); err == nil {
+		t.Error("identifiers accepted an override colliding with a generated identifier")
+	}
+
+	n.Overrides = map[snowflake.ID]string{20: "news"}
+	if _, err := n.identifiers(entities); err == nil {
+		t.Error("identifiers accepted an override that is not exported")
+	}
+}
+
+// TestGuildNamerReserved makes sure a guild namespace can't make an identifier declared by the enum templates
+func TestGuildNamerReserved(t *testing.T) {
+	n, err := guildNamer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := n.identifiers([]namedEntity{{ID: 10, Name: "guild channel"}, {ID: 20, Name: "galaxy"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// GuildChannel + Categories is declared by the channel template
+	if want := map[snowflake.ID]string{10: "GuildChannel_10", 20: "Galaxy"}; !maps.Equal(got, want) {
+		t.Errorf("identifiers = %v, want %v", got, want)
+	}
+}
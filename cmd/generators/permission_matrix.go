@@ -0,0 +1,205 @@
+package generators
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/bil0u/galaxy-os/sdk/utils"
+	"github.com/disgoorg/disgo/discord"
+)
+
+var PermissionMatrixMarkdownGenerator = &utils.TextFileGenerator{
+	OutputFile: "docs/permissions.md",
+	GetData:    getPermissionMatrix,
+	Write:      writePermissionMatrixMarkdown,
+}
+
+var PermissionMatrixCSVGenerator = &utils.TextFileGenerator{
+	OutputFile: "docs/permissions.csv",
+	GetData:    getPermissionMatrix,
+	Write:      writePermissionMatrixCSV,
+}
+
+// channelAccess is what a member having only @everyone and a role can do in a channel
+type channelAccess struct {
+	View    bool
+	Send    bool
+	Connect bool
+	Manage  bool
+}
+
+type permissionMatrixRow struct {
+	Category string
+	Channel  discord.GuildChannel
+	// Synced is true if the channel has the permission overwrites of its category
+	Synced bool
+	// Access of each role, in the order of the roles of the guild
+	Access []channelAccess
+}
+
+type permissionMatrixGuild struct {
+	Name  string
+	Roles []discord.Role
+	Rows  []permissionMatrixRow
+}
+
+type permissionMatrixData struct {
+	Guilds []permissionMatrixGuild
+}
+
+func getPermissionMatrix(g *utils.TextFileGenerator) (any, error) {
+	// Fetch guilds from the source
+	namespaces, err := fetchGuildNamespaces(g.Source, g.Cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch permissions: %w", err)
+	}
+
+	var data permissionMatrixData
+	for _, namespace := range namespaces {
+		guild := namespace.Guild
+		everyone := utils.Find(guild.Roles, func(role discord.Role) bool { return role.ID == guild.ID })
+		if everyone == nil {
+			return nil, fmt.Errorf("guild '%s' has no @everyone role", guild.ID)
+		}
+
+		matrix := permissionMatrixGuild{Name: guild.Name, Roles: guildRoles(guild)}
+		categories, channels := sortGuildChannels(guild.Channels)
+		for _, channel := range channels {
+			row := permissionMatrixRow{Channel: channel, Synced: true}
+			if parent := getParentChannel(channel, categories); parent != nil {
+				row.Category = (*parent).Name()
+				row.Synced = sameOverwrites(channel.PermissionOverwrites(), (*parent).PermissionOverwrites())
+			}
+			for _, role := range matrix.Roles {
+				row.Access = append(row.Access, accessOf(channel, *everyone, role))
+			}
+			matrix.Rows = append(matrix.Rows, row)
+		}
+		data.Guilds = append(data.Guilds, matrix)
+	}
+	return data, nil
+}
+
+// accessOf computes the permissions of a member having only @everyone and the given role in a channel,
+// as discord does: base permissions of the roles, then the overwrites of @everyone, then the ones of the role
+func accessOf(channel discord.GuildChannel, everyone discord.Role, role discord.Role) channelAccess {
+	permissions := everyone.Permissions | role.Permissions
+	if permissions.Has(discord.PermissionAdministrator) {
+		// Administrators have every permission, overwrites don't apply to them
+		permissions = discord.PermissionsAll
+	} else {
+		overwrites := channel.PermissionOverwrites()
+		if overwrite, ok := overwrites.Role(everyone.ID); ok {
+			permissions = permissions.Remove(overwrite.Deny).Add(overwrite.Allow)
+		}
+		if overwrite, ok := overwrites.Role(role.ID); ok && role.ID != everyone.ID {
+			permissions = permissions.Remove(overwrite.Deny).Add(overwrite.Allow)
+		}
+	}
+
+	// Members who can't see a channel can't do anything in it
+	if !permissions.Has(discord.PermissionViewChannel) {
+		return channelAccess{}
+	}
+	return channelAccess{
+		View:    true,
+		Send:    permissions.Has(discord.PermissionSendMessages),
+		Connect: channel.Type() == discord.ChannelTypeGuildVoice && permissions.Has(discord.PermissionConnect),
+		Manage:  permissions.Has(discord.PermissionManageChannels),
+	}
+}
+
+// sameOverwrites returns true if both channels have the same permission overwrites, in any order
+func sameOverwrites(a discord.PermissionOverwrites, b discord.PermissionOverwrites) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for _, overwrite := range a {
+		if other, ok := b.Get(overwrite.Type(), overwrite.ID()); !ok || other != overwrite {
+			return false
+		}
+	}
+	return true
+}
+
+// String returns the letters of the allowed permissions, e.g. "VS" if members can view a channel and send messages
+func (a channelAccess) String() string {
+	var b strings.Builder
+	for _, p := range []struct {
+		allowed bool
+		letter  string
+	}{{a.View, "V"}, {a.Send, "S"}, {a.Connect, "C"}, {a.Manage, "M"}} {
+		if p.allowed {
+			b.WriteString(p.letter)
+		}
+	}
+	if b.Len() == 0 {
+		return "-"
+	}
+	return b.String()
+}
+
+const permissionMatrixLegend = `Effective permissions of a member having only @everyone and the role of the column, member overwrites are ignored.
+
+V: view the channel, S: send messages, C: connect to the voice channel, M: manage the channel, -: no access.
+Channels marked with * don't sync their permissions with their category.
+`
+
+func writePermissionMatrixMarkdown(w io.Writer, data any) error {
+	var b strings.Builder
+	b.WriteString("<!-- Code generated by go generate; DO NOT EDIT. -->\n\n# Permissions\n\n")
+	b.WriteString(permissionMatrixLegend)
+	for _, guild := range data.(permissionMatrixData).Guilds {
+		fmt.Fprintf(&b, "\n## %s\n", escapeMarkdownCell(guild.Name))
+		header := []string{"Channel"}
+		for _, role := range guild.Roles {
+			header = append(header, escapeMarkdownCell(role.Name))
+		}
+
+		// One table per category, channels without category come first
+		for i, row := range guild.Rows {
+			if i == 0 || row.Category != guild.Rows[i-1].Category {
+				if row.Category != "" {
+					fmt.Fprintf(&b, "\n### %s\n", escapeMarkdownCell(row.Category))
+				}
+				b.WriteString("\n| " + strings.Join(header, " | ") + " |\n")
+				b.WriteString("|" + strings.Repeat(" --- |", len(header)) + "\n")
+			}
+			name := escapeMarkdownCell(row.Channel.Name())
+			if !row.Synced {
+				name += " *"
+			}
+			cells := []string{name}
+			for _, access := range row.Access {
+				cells = append(cells, access.String())
+			}
+			b.WriteString("| " + strings.Join(cells, " | ") + " |\n")
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func escapeMarkdownCell(s string) string {
+	return strings.ReplaceAll(s, "|", `\|`)
+}
+
+func writePermissionMatrixCSV(w io.Writer, data any) error {
+	records := [][]string{{"guild", "category", "channel", "channel_id", "synced", "role", "role_id", "view", "send", "connect", "manage"}}
+	for _, guild := range data.(permissionMatrixData).Guilds {
+		for _, row := range guild.Rows {
+			for i, access := range row.Access {
+				role := guild.Roles[i]
+				records = append(records, []string{
+					guild.Name, row.Category, row.Channel.Name(), row.Channel.ID().String(), strconv.FormatBool(row.Synced),
+					role.Name, role.ID.String(),
+					strconv.FormatBool(access.View), strconv.FormatBool(access.Send), strconv.FormatBool(access.Connect), strconv.FormatBool(access.Manage),
+				})
+			}
+		}
+	}
+	return csv.NewWriter(w).WriteAll(records)
+}
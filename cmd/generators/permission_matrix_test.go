@@ -0,0 +1,98 @@
+package generators
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+const (
+	testEveryoneID = 100
+	testRoleID     = 200
+)
+
+// testChannel returns a guild channel of the given type with the given overwrites, read from JSON as in snapshots
+func testChannel(t *testing.T, channelType discord.ChannelType, overwrites ...discord.RolePermissionOverwrite) discord.GuildChannel {
+	t.Helper()
+	data, err := json.Marshal(map[string]any{
+		"id":                    "300",
+		"guild_id":              "100",
+		"type":                  channelType,
+		"name":                  "test",
+		"permission_overwrites": append([]discord.RolePermissionOverwrite{}, overwrites...),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var channel discord.UnmarshalChannel
+	if err := json.Unmarshal(data, &channel); err != nil {
+		t.Fatal(err)
+	}
+	return channel.Channel.(discord.GuildChannel)
+}
+
+func TestAccessOf(t *testing.T) {
+	everyone := discord.Role{ID: testEveryoneID, Permissions: discord.PermissionViewChannel | discord.PermissionSendMessages | discord.PermissionConnect}
+	member := discord.Role{ID: testRoleID}
+	admin := discord.Role{ID: testRoleID, Permissions: discord.PermissionAdministrator}
+	hideFromEveryone := discord.RolePermissionOverwrite{RoleID: testEveryoneID, Deny: discord.PermissionViewChannel}
+	allowRole := discord.RolePermissionOverwrite{RoleID: testRoleID, Allow: discord.PermissionViewChannel | discord.PermissionManageChannels}
+
+	tests := []struct {
+		name    string
+		channel discord.GuildChannel
+		role    discord.Role
+		want    channelAccess
+	}{
+		{
+			name:    "no connect in text channels",
+			channel: testChannel(t, discord.ChannelTypeGuildText),
+			role:    member,
+			want:    channelAccess{View: true, Send: true},
+		},
+		{
+			name:    "admin bypass",
+			channel: testChannel(t, discord.ChannelTypeGuildText, hideFromEveryone),
+			role:    admin,
+			want:    channelAccess{View: true, Send: true, Manage: true},
+		},
+		{
+			name:    "everyone deny",
+			channel: testChannel(t, discord.ChannelTypeGuildText, hideFromEveryone),
+			role:    member,
+			want:    channelAccess{},
+		},
+		{
+			name:    "role allow overwrite",
+			channel: testChannel(t, discord.ChannelTypeGuildText, hideFromEveryone, allowRole),
+			role:    member,
+			want:    channelAccess{View: true, Send: true, Manage: true},
+		},
+		{
+			name:    "role deny overwrite",
+			channel: testChannel(t, discord.ChannelTypeGuildText, discord.RolePermissionOverwrite{RoleID: testRoleID, Deny: discord.PermissionSendMessages}),
+			role:    member,
+			want:    channelAccess{View: true},
+		},
+		{
+			name:    "voice connect",
+			channel: testChannel(t, discord.ChannelTypeGuildVoice),
+			role:    member,
+			want:    channelAccess{View: true, Send: true, Connect: true},
+		},
+		{
+			name:    "no view short-circuit",
+			channel: testChannel(t, discord.ChannelTypeGuildVoice, discord.RolePermissionOverwrite{RoleID: testRoleID, Deny: discord.PermissionViewChannel}),
+			role:    member,
+			want:    channelAccess{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := accessOf(tt.channel, everyone, tt.role); got != tt.want {
+				t.Errorf("accessOf = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,66 +1,69 @@
 package generators
 
 import (
-	"html/template"
-	"log"
-	"regexp"
+	"fmt"
 	"slices"
-	"strings"
-	"unicode"
 
+	"github.com/bil0u/galaxy-os/sdk/snapshot"
 	"github.com/bil0u/galaxy-os/sdk/utils"
-	"github.com/disgoorg/disgo/bot"
 	"github.com/disgoorg/disgo/discord"
-	"github.com/disgoorg/snowflake/v2"
-	"golang.org/x/text/runes"
-	"golang.org/x/text/transform"
-	"golang.org/x/text/unicode/norm"
 )
 
 var RoleEnumGenerator = &utils.SourceFileGenerator{
 	OutputFile: "sdk/enums/role.go",
 	Header:     utils.GeneratedFileHeader,
-	TemplateFuncs: template.FuncMap{
-		"FormatRoleName": formatRoleName,
-	},
-	GetData:  getDiscordRoles,
-	Template: roleEnumTemplate,
+	GetData:    getDiscordRoles,
+	Template:   roleEnumTemplate,
 }
 
-type roleEnumGeneratorData struct {
-	Roles []discord.Role
+type roleEnumValue struct {
+	Identifier string
+	discord.Role
 }
 
-func formatRoleName(name string) string {
-	// Remove diacritics
-	t := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
-	name, _, _ = transform.String(t, name)
-	// Remove special characters
-	return regexp.MustCompile(`[\P{L}+]`).ReplaceAllString(strings.Title(name), "")
+type roleEnumGuild struct {
+	guildNamespace
+	Roles []roleEnumValue
 }
 
-func fetchRoles(client bot.Client, Guilds []snowflake.ID) ([]discord.Role, error) {
-	guilds := client.Rest()
-	var roles []discord.Role
-	for _, guildID := range Guilds {
-		guildRoles, err := guilds.GetRoles(guildID)
-		if err != nil {
-			return nil, err
-		}
-		roles = append(roles, guildRoles...)
-	}
-	return roles, nil
+type roleEnumGeneratorData struct {
+	Guilds []roleEnumGuild
 }
 
-func getDiscordRoles(g *utils.SourceFileGenerator) any {
-	// Fetch roles from Discord
-	roles, err := fetchRoles(g.Client, g.Cfg.Guilds)
+func getDiscordRoles(g *utils.SourceFileGenerator) (any, error) {
+	// Fetch guilds from the source
+	namespaces, err := fetchGuildNamespaces(g.Source, g.Cfg)
 	if err != nil {
-		log.Fatalf("Failed to fetch roles: %v", err)
+		return nil, fmt.Errorf("failed to fetch roles: %w", err)
+	}
+
+	var data roleEnumGeneratorData
+	for _, namespace := range namespaces {
+		roles := guildRoles(namespace.Guild)
+
+		// Naming them
+		entities := make([]namedEntity, len(roles))
+		for i, role := range roles {
+			entities[i] = namedEntity{ID: role.ID, Name: role.Name}
+		}
+		identifiers, err := enumNamer("Role", g.Cfg).identifiers(entities)
+		if err != nil {
+			return nil, fmt.Errorf("failed to name the roles of guild '%s': %w", namespace.Guild.ID, err)
+		}
+
+		guild := roleEnumGuild{guildNamespace: namespace}
+		for _, role := range roles {
+			guild.Roles = append(guild.Roles, roleEnumValue{Identifier: identifiers[role.ID], Role: role})
+		}
+		data.Guilds = append(data.Guilds, guild)
 	}
+	return data, nil
+}
 
+// guildRoles returns the roles of a guild without the bot roles, from the highest to the lowest
+func guildRoles(guild *snapshot.Guild) []discord.Role {
 	// Filtering roles to remove bot roles
-	roles = utils.Filter(roles, func(role discord.Role) bool {
+	roles := utils.Filter(guild.Roles, func(role discord.Role) bool {
 		return role.Tags == nil || role.Tags.BotID == nil
 	})
 
@@ -68,41 +71,130 @@ func getDiscordRoles(g *utils.SourceFileGenerator) any {
 	slices.SortStableFunc(roles, func(r1 discord.Role, r2 discord.Role) int {
 		return r2.Position - r1.Position
 	})
-
-	return roleEnumGeneratorData{Roles: roles}
+	return roles
 }
 
 const roleEnumTemplate = `
 package enums
 
 import (
+	"fmt"
+	"slices"
+
+	"github.com/disgoorg/disgo/discord"
 	"github.com/disgoorg/snowflake/v2"
 )
 
-type RoleEnum string
+// RoleEnum is a role of one of the guilds, identified by its ID
+type RoleEnum snowflake.ID
+{{ range .Guilds }}
+// {{ ident .Namespace }}Roles are the roles of the guild '{{ comment .Guild.Name }}'
+var {{ ident .Namespace }}Roles = struct {
+{{- range .Roles }}
+	{{ ident .Identifier }} RoleEnum
+{{- end }}
+}{
+{{- range .Roles }}
+	{{ ident .Identifier }}: {{ .ID }},
+{{- end }}
+}
+{{ end }}
+// roles are the roles of every guild, guild by guild, from the highest to the lowest
+var roles = []RoleEnum{
+{{- range .Guilds }}
+{{- range .Roles }}
+	{{ .ID }},
+{{- end }}
+{{- end }}
+}
 
-const (
+// roleExpressions maps the Go expressions of the roles, e.g. GalaxyRoles.Capitaine, to the roles
+var roleExpressions = map[string]RoleEnum{
+{{- range .Guilds }}
+{{- $namespace := .Namespace }}
 {{- range .Roles }}
-    Role{{ FormatRoleName .Name }} RoleEnum = "{{ .Name }}"
+	{{ quote (printf "%sRoles.%s" $namespace .Identifier) }}: {{ .ID }},
 {{- end }}
-)
+{{- end }}
+}
+
+// RoleNames maps the roles of every guild to their name
+var RoleNames = map[RoleEnum]string{
+{{- range .Guilds }}
+{{- range .Roles }}
+	{{ .ID }}: {{ quote .Name }},
+{{- end }}
+{{- end }}
+}
 
-var RoleMap = map[RoleEnum]snowflake.ID{
+// RoleGuilds maps the roles of every guild to their guild ID
+var RoleGuilds = map[RoleEnum]snowflake.ID{
+{{- range .Guilds }}
+{{- $guildID := .Guild.ID }}
 {{- range .Roles }}
-    Role{{ FormatRoleName .Name }}: {{ .ID }},
+	{{ .ID }}: {{ $guildID }},
+{{- end }}
 {{- end }}
 }
 
 func (e RoleEnum) String() string {
-	return string(e)
+	return RoleNames[e]
 }
 
 func (e RoleEnum) ID() snowflake.ID {
-	return RoleMap[e]
+	return snowflake.ID(e)
+}
+
+func (e RoleEnum) GuildID() snowflake.ID {
+	return RoleGuilds[e]
 }
 
 func (e RoleEnum) IsValid() bool {
-	_, ok := RoleMap[e]
+	_, ok := RoleNames[e]
 	return ok
 }
+
+// Mention returns the mention of the role to use in messages
+func (e RoleEnum) Mention() string {
+	return discord.RoleMention(e.ID())
+}
+
+// MarshalText writes the role as its ID
+func (e RoleEnum) MarshalText() ([]byte, error) {
+	return []byte(e.ID().String()), nil
+}
+
+// UnmarshalText reads a role from its ID or its Go expression, e.g. GalaxyRoles.Capitaine
+func (e *RoleEnum) UnmarshalText(text []byte) error {
+	if role, ok := roleExpressions[string(text)]; ok {
+		*e = role
+		return nil
+	}
+	id, err := snowflake.Parse(string(text))
+	if err != nil || !RoleEnum(id).IsValid() {
+		return fmt.Errorf("unknown role '%s'", text)
+	}
+	*e = RoleEnum(id)
+	return nil
+}
+
+// AllRoles returns the roles of every guild, guild by guild, from the highest to the lowest
+func AllRoles() []RoleEnum {
+	return slices.Clone(roles)
+}
+
+// RoleFromID returns the role having the ID, or false if there is none
+func RoleFromID(id snowflake.ID) (RoleEnum, bool) {
+	return RoleEnum(id), RoleEnum(id).IsValid()
+}
+
+// RoleFromName returns the highest role of the guild having the name, or false if there is none
+func RoleFromName(guildID snowflake.ID, name string) (RoleEnum, bool) {
+	for _, role := range roles {
+		if role.GuildID() == guildID && role.String() == name {
+			return role, true
+		}
+	}
+	return 0, false
+}
 `
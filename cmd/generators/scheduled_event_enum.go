@@ -0,0 +1,127 @@
+package generators
+
+import (
+	"fmt"
+
+	"github.com/bil0u/galaxy-os/sdk/utils"
+	"github.com/disgoorg/disgo/discord"
+)
+
+var ScheduledEventEnumGenerator = &utils.SourceFileGenerator{
+	OutputFile: "sdk/enums/scheduled_event.go",
+	Header:     utils.GeneratedFileHeader,
+	GetData:    getDiscordScheduledEvents,
+	Template:   scheduledEventEnumTemplate,
+}
+
+type scheduledEventEnumValue struct {
+	Identifier string
+	discord.GuildScheduledEvent
+}
+
+type scheduledEventEnumGuild struct {
+	guildNamespace
+	ScheduledEvents []scheduledEventEnumValue
+}
+
+type scheduledEventEnumGeneratorData struct {
+	Guilds []scheduledEventEnumGuild
+}
+
+func getDiscordScheduledEvents(g *utils.SourceFileGenerator) (any, error) {
+	// Fetch guilds from the source
+	namespaces, err := fetchGuildNamespaces(g.Source, g.Cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch scheduled events: %w", err)
+	}
+
+	var data scheduledEventEnumGeneratorData
+	for _, namespace := range namespaces {
+		// Keeping recurring events only, one-time events don't last long enough to be referenced in code
+		events := utils.Filter(namespace.Guild.ScheduledEvents, func(event discord.GuildScheduledEvent) bool {
+			return event.RecurrenceRule != nil
+		})
+
+		// Naming them
+		entities := make([]namedEntity, len(events))
+		for i, event := range events {
+			entities[i] = namedEntity{ID: event.ID, Name: event.Name}
+		}
+		identifiers, err := enumNamer("Event", g.Cfg).identifiers(entities)
+		if err != nil {
+			return nil, fmt.Errorf("failed to name the scheduled events of guild '%s': %w", namespace.Guild.ID, err)
+		}
+
+		guild := scheduledEventEnumGuild{guildNamespace: namespace}
+		for _, event := range events {
+			guild.ScheduledEvents = append(guild.ScheduledEvents, scheduledEventEnumValue{Identifier: identifiers[event.ID], GuildScheduledEvent: event})
+		}
+		data.Guilds = append(data.Guilds, guild)
+	}
+	return data, nil
+}
+
+const scheduledEventEnumTemplate = `
+package enums
+
+import (
+	"fmt"
+
+	"github.com/disgoorg/snowflake/v2"
+)
+
+// ScheduledEventEnum is a recurring scheduled event of one of the guilds, identified by its ID
+type ScheduledEventEnum snowflake.ID
+{{ range .Guilds }}
+// {{ ident .Namespace }}ScheduledEvents are the recurring scheduled events of the guild '{{ comment .Guild.Name }}'
+var {{ ident .Namespace }}ScheduledEvents = struct {
+{{- range .ScheduledEvents }}
+	{{ ident .Identifier }} ScheduledEventEnum
+{{- end }}
+}{
+{{- range .ScheduledEvents }}
+	{{ ident .Identifier }}: {{ .ID }},
+{{- end }}
+}
+{{ end }}
+// ScheduledEventNames maps the recurring scheduled events of every guild to their name
+var ScheduledEventNames = map[ScheduledEventEnum]string{
+{{- range .Guilds }}
+{{- range .ScheduledEvents }}
+	{{ .ID }}: {{ quote .Name }},
+{{- end }}
+{{- end }}
+}
+
+// ScheduledEventGuilds maps the recurring scheduled events of every guild to their guild ID
+var ScheduledEventGuilds = map[ScheduledEventEnum]snowflake.ID{
+{{- range .Guilds }}
+{{- $guildID := .Guild.ID }}
+{{- range .ScheduledEvents }}
+	{{ .ID }}: {{ $guildID }},
+{{- end }}
+{{- end }}
+}
+
+func (e ScheduledEventEnum) String() string {
+	return ScheduledEventNames[e]
+}
+
+func (e ScheduledEventEnum) ID() snowflake.ID {
+	return snowflake.ID(e)
+}
+
+func (e ScheduledEventEnum) GuildID() snowflake.ID {
+	return ScheduledEventGuilds[e]
+}
+
+func (e ScheduledEventEnum) IsValid() bool {
+	_, ok := ScheduledEventNames[e]
+	return ok
+}
+
+// URL returns the link to the event, discord shows it as the event card in messages
+func (e ScheduledEventEnum) URL() string {
+	return fmt.Sprintf("https://discord.com/events/%s/%s", e.GuildID(), e.ID())
+}
+`
@@ -0,0 +1,118 @@
+package generators
+
+import (
+	"fmt"
+
+	"github.com/bil0u/galaxy-os/sdk/utils"
+	"github.com/disgoorg/disgo/discord"
+)
+
+var StickerEnumGenerator = &utils.SourceFileGenerator{
+	OutputFile: "sdk/enums/sticker.go",
+	Header:     utils.GeneratedFileHeader,
+	GetData:    getDiscordStickers,
+	Template:   stickerEnumTemplate,
+}
+
+type stickerEnumValue struct {
+	Identifier string
+	discord.Sticker
+}
+
+type stickerEnumGuild struct {
+	guildNamespace
+	Stickers []stickerEnumValue
+}
+
+type stickerEnumGeneratorData struct {
+	Guilds []stickerEnumGuild
+}
+
+func getDiscordStickers(g *utils.SourceFileGenerator) (any, error) {
+	// Fetch guilds from the source
+	namespaces, err := fetchGuildNamespaces(g.Source, g.Cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch stickers: %w", err)
+	}
+
+	var data stickerEnumGeneratorData
+	for _, namespace := range namespaces {
+		// Stickers are sorted by ID in snapshots, the oldest first
+		stickers := namespace.Guild.Stickers
+
+		// Naming them
+		entities := make([]namedEntity, len(stickers))
+		for i, sticker := range stickers {
+			entities[i] = namedEntity{ID: sticker.ID, Name: sticker.Name}
+		}
+		identifiers, err := enumNamer("Sticker", g.Cfg).identifiers(entities)
+		if err != nil {
+			return nil, fmt.Errorf("failed to name the stickers of guild '%s': %w", namespace.Guild.ID, err)
+		}
+
+		guild := stickerEnumGuild{guildNamespace: namespace}
+		for _, sticker := range stickers {
+			guild.Stickers = append(guild.Stickers, stickerEnumValue{Identifier: identifiers[sticker.ID], Sticker: sticker})
+		}
+		data.Guilds = append(data.Guilds, guild)
+	}
+	return data, nil
+}
+
+const stickerEnumTemplate = `
+package enums
+
+import (
+	"github.com/disgoorg/snowflake/v2"
+)
+
+// StickerEnum is a sticker of one of the guilds, identified by its ID
+type StickerEnum snowflake.ID
+{{ range .Guilds }}
+// {{ ident .Namespace }}Stickers are the stickers of the guild '{{ comment .Guild.Name }}'
+var {{ ident .Namespace }}Stickers = struct {
+{{- range .Stickers }}
+	{{ ident .Identifier }} StickerEnum
+{{- end }}
+}{
+{{- range .Stickers }}
+	{{ ident .Identifier }}: {{ .ID }},
+{{- end }}
+}
+{{ end }}
+// StickerNames maps the stickers of every guild to their name
+var StickerNames = map[StickerEnum]string{
+{{- range .Guilds }}
+{{- range .Stickers }}
+	{{ .ID }}: {{ quote .Name }},
+{{- end }}
+{{- end }}
+}
+
+// StickerGuilds maps the stickers of every guild to their guild ID
+var StickerGuilds = map[StickerEnum]snowflake.ID{
+{{- range .Guilds }}
+{{- $guildID := .Guild.ID }}
+{{- range .Stickers }}
+	{{ .ID }}: {{ $guildID }},
+{{- end }}
+{{- end }}
+}
+
+func (e StickerEnum) String() string {
+	return StickerNames[e]
+}
+
+func (e StickerEnum) ID() snowflake.ID {
+	return snowflake.ID(e)
+}
+
+func (e StickerEnum) GuildID() snowflake.ID {
+	return StickerGuilds[e]
+}
+
+func (e StickerEnum) IsValid() bool {
+	_, ok := StickerNames[e]
+	return ok
+}
+`
@@ -0,0 +1,393 @@
+package generators
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/bil0u/galaxy-os/sdk"
+	"github.com/bil0u/galaxy-os/sdk/snapshot"
+	"github.com/bil0u/galaxy-os/sdk/utils"
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/snowflake/v2"
+)
+
+// templateSources read the items of a guild for each source of the template file generators
+var templateSources = map[string]func(guild *snapshot.Guild, cfg sdk.BotConfig, gen sdk.GeneratorConfig) ([]templateItem, error){
+	"roles":    roleItems,
+	"channels": channelItems,
+	"emojis":   emojiItems,
+	"members":  memberItems,
+}
+
+// NeedsMembers returns true if one of the template file generators reads the members of the guilds,
+// they are only fetched then, as they need the privileged members intent
+func NeedsMembers(gens []sdk.GeneratorConfig) bool {
+	return slices.ContainsFunc(gens, func(gen sdk.GeneratorConfig) bool { return gen.Source == "members" })
+}
+
+// templateFuncs are the functions available in templates and in the filter and sort expressions
+var templateFuncs = template.FuncMap{
+	"json": func(v any) (string, error) {
+		var b strings.Builder
+		encoder := json.NewEncoder(&b)
+		encoder.SetEscapeHTML(false)
+		encoder.SetIndent("", "  ")
+		err := encoder.Encode(v)
+		return strings.TrimSuffix(b.String(), "\n"), err
+	},
+	"quote":    strconv.Quote,
+	"lower":    strings.ToLower,
+	"upper":    strings.ToUpper,
+	"contains": strings.Contains,
+	"join":     strings.Join,
+	"hasID": func(ids []snowflake.ID, id snowflake.ID) bool {
+		return slices.Contains(ids, id)
+	},
+}
+
+// templateFileData is the data given to the templates, documented in docs/generators.md
+type templateFileData struct {
+	// Items of every guild, filtered then sorted
+	Items  []templateItem  `json:"items"`
+	Guilds []templateGuild `json:"guilds"`
+}
+
+type templateGuild struct {
+	ID        snowflake.ID   `json:"id"`
+	Name      string         `json:"name"`
+	Namespace string         `json:"namespace"`
+	Items     []templateItem `json:"items"`
+}
+
+// templateItem is a role, channel, emoji or member given to the templates
+type templateItem struct {
+	ID snowflake.ID `json:"id"`
+	// Type is "role", "emoji", "member", or the type of the channel: "category", "text", "voice", "news" or "forum"
+	Type string `json:"type"`
+	Name string `json:"name"`
+	// Identifier is the name of the item in the generated enums, members are named the same way
+	Identifier string       `json:"identifier"`
+	Mention    string       `json:"mention"`
+	GuildID    snowflake.ID `json:"guild_id"`
+	Guild      string       `json:"guild"`
+	// Position of roles and channels
+	Position int `json:"position,omitempty"`
+	// Category of channels
+	Category string `json:"category,omitempty"`
+	// Color of roles
+	Color int `json:"color,omitempty"`
+	// Animated is true for animated emojis
+	Animated bool `json:"animated,omitempty"`
+	// Roles of members, and the roles allowed to use an emoji
+	Roles []snowflake.ID `json:"roles,omitempty"`
+	// Discord is the object read from discord: a discord.Role, discord.GuildChannel, discord.Emoji or discord.Member
+	Discord any `json:"-"`
+}
+
+// NewTemplateFileGenerator returns a generator rendering the template file declared in the config
+func NewTemplateFileGenerator(gen sdk.GeneratorConfig) (*utils.TextFileGenerator, error) {
+	if _, ok := templateSources[gen.Source]; !ok {
+		return nil, fmt.Errorf("unknown source '%s' for generator '%s'", gen.Source, gen.Output)
+	}
+	if gen.Template == "" || gen.Output == "" {
+		return nil, fmt.Errorf("generator of source '%s' needs a template and an output", gen.Source)
+	}
+	filter, err := parseExpression("filter", gen.Filter, "{{ if %s }}true{{ end }}")
+	if err != nil {
+		return nil, err
+	}
+	sortKey, err := parseExpression("sort", gen.Sort, "{{ %s }}")
+	if err != nil {
+		return nil, err
+	}
+
+	return &utils.TextFileGenerator{
+		OutputFile: gen.Output,
+		GetData: func(g *utils.TextFileGenerator) (any, error) {
+			return getTemplateFileData(g, gen, filter, sortKey)
+		},
+		Write: func(w io.Writer, data any) error {
+			content, err := os.ReadFile(gen.Template)
+			if err != nil {
+				return err
+			}
+			tmpl, err := template.New(gen.Template).Funcs(templateFuncs).Option("missingkey=error").Parse(string(content))
+			if err != nil {
+				return err
+			}
+			return tmpl.Execute(w, data)
+		},
+	}, nil
+}
+
+// parseExpression parses a filter or sort expression of the config, it returns nil if there is none
+func parseExpression(name string, expression string, format string) (*template.Template, error) {
+	if expression == "" {
+		return nil, nil
+	}
+	tmpl, err := template.New(name).Funcs(templateFuncs).Parse(fmt.Sprintf(format, expression))
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s expression '%s': %w", name, expression, err)
+	}
+	return tmpl, nil
+}
+
+func evalExpression(tmpl *template.Template, item templateItem) (string, error) {
+	var b strings.Builder
+	if err := tmpl.Execute(&b, item); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+func getTemplateFileData(g *utils.TextFileGenerator, gen sdk.GeneratorConfig, filter *template.Template, sortKey *template.Template) (templateFileData, error) {
+	var data templateFileData
+
+	// Fetch guilds from the source
+	namespaces, err := fetchGuildNamespaces(g.Source, g.Cfg)
+	if err != nil {
+		return data, fmt.Errorf("failed to fetch %s: %w", gen.Source, err)
+	}
+
+	for _, namespace := range namespaces {
+		items, err := templateSources[gen.Source](namespace.Guild, g.Cfg, gen)
+		if err != nil {
+			return data, fmt.Errorf("failed to read the %s of guild '%s': %w", gen.Source, namespace.Guild.ID, err)
+		}
+		if items, err = filterItems(items, filter); err != nil {
+			return data, err
+		}
+		if items, err = sortItems(items, sortKey, gen.Descending); err != nil {
+			return data, err
+		}
+		data.Guilds = append(data.Guilds, templateGuild{ID: namespace.Guild.ID, Name: namespace.Guild.Name, Namespace: namespace.Namespace, Items: items})
+		data.Items = append(data.Items, items...)
+	}
+
+	// Items of every guild are sorted together
+	if data.Items, err = sortItems(data.Items, sortKey, gen.Descending); err != nil {
+		return data, err
+	}
+	return data, nil
+}
+
+func filterItems(items []templateItem, filter *template.Template) ([]templateItem, error) {
+	if filter == nil {
+		return items, nil
+	}
+	var kept []templateItem
+	for _, item := range items {
+		result, err := evalExpression(filter, item)
+		if err != nil {
+			return nil, fmt.Errorf("failed to filter '%s': %w", item.Name, err)
+		}
+		if result != "" {
+			kept = append(kept, item)
+		}
+	}
+	return kept, nil
+}
+
+// sortItems sorts items by the key of the sort expression, keys are compared as numbers if they all are,
+// as strings otherwise. Items keep their order if there is no sort expression
+func sortItems(items []templateItem, sortKey *template.Template, descending bool) ([]templateItem, error) {
+	if sortKey == nil {
+		return items, nil
+	}
+	// Keys are kept by index, as items of different guilds or sources may share an ID
+	keys := make([]string, len(items))
+	numeric := true
+	for i, item := range items {
+		key, err := evalExpression(sortKey, item)
+		if err != nil {
+			return nil, fmt.Errorf("failed to sort '%s': %w", item.Name, err)
+		}
+		if _, err := strconv.ParseFloat(key, 64); err != nil {
+			numeric = false
+		}
+		keys[i] = key
+	}
+
+	compare := func(i, j int) int {
+		if numeric {
+			x, _ := strconv.ParseFloat(keys[i], 64)
+			y, _ := strconv.ParseFloat(keys[j], 64)
+			return cmp.Compare(x, y)
+		}
+		return cmp.Compare(keys[i], keys[j])
+	}
+	order := make([]int, len(items))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(i, j int) int {
+		if descending {
+			return compare(j, i)
+		}
+		return compare(i, j)
+	})
+	sorted := make([]templateItem, len(items))
+	for i, index := range order {
+		sorted[i] = items[index]
+	}
+	return sorted, nil
+}
+
+// Sources
+
+func roleItems(guild *snapshot.Guild, cfg sdk.BotConfig, _ sdk.GeneratorConfig) ([]templateItem, error) {
+	roles := guildRoles(guild)
+	entities := make([]namedEntity, len(roles))
+	for i, role := range roles {
+		entities[i] = namedEntity{ID: role.ID, Name: role.Name}
+	}
+	identifiers, err := enumNamer("Role", cfg).identifiers(entities)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]templateItem, len(roles))
+	for i, role := range roles {
+		items[i] = templateItem{
+			ID:         role.ID,
+			Type:       "role",
+			Name:       role.Name,
+			Identifier: identifiers[role.ID],
+			Mention:    discord.RoleMention(role.ID),
+			GuildID:    guild.ID,
+			Guild:      guild.Name,
+			Position:   role.Position,
+			Color:      role.Color,
+			Discord:    role,
+		}
+	}
+	return items, nil
+}
+
+// channelTypes name the channel types in templates
+var channelTypes = map[discord.ChannelType]string{
+	discord.ChannelTypeGuildCategory: "category",
+	discord.ChannelTypeGuildText:     "text",
+	discord.ChannelTypeGuildVoice:    "voice",
+	discord.ChannelTypeGuildNews:     "news",
+	discord.ChannelTypeGuildForum:    "forum",
+}
+
+// channelItems returns the channels in server order: channels without category first, then each category
+// followed by its channels
+func channelItems(guild *snapshot.Guild, cfg sdk.BotConfig, _ sdk.GeneratorConfig) ([]templateItem, error) {
+	categories, channels := sortGuildChannels(guild.Channels)
+	namedCategories, err := nameChannels("Category", cfg, categories)
+	if err != nil {
+		return nil, err
+	}
+	namedChannels, err := nameChannels("Channel", cfg, channels)
+	if err != nil {
+		return nil, err
+	}
+
+	item := func(channel channelEnumValue, category string) templateItem {
+		return templateItem{
+			ID:         channel.ID(),
+			Type:       channelTypes[channel.Type()],
+			Name:       channel.Name(),
+			Identifier: channel.Identifier,
+			Mention:    discord.ChannelMention(channel.ID()),
+			GuildID:    guild.ID,
+			Guild:      guild.Name,
+			Position:   channel.Position(),
+			Category:   category,
+			Discord:    channel.GuildChannel,
+		}
+	}
+	inCategory := func(channel channelEnumValue, categoryID *snowflake.ID) bool {
+		parentID := channel.ParentID()
+		if parentID == nil || categoryID == nil {
+			return parentID == categoryID
+		}
+		return *parentID == *categoryID
+	}
+
+	var items []templateItem
+	for _, channel := range namedChannels {
+		if inCategory(channel, nil) {
+			items = append(items, item(channel, ""))
+		}
+	}
+	for _, category := range namedCategories {
+		items = append(items, item(category, ""))
+		categoryID := category.ID()
+		for _, channel := range namedChannels {
+			if inCategory(channel, &categoryID) {
+				items = append(items, item(channel, category.Name()))
+			}
+		}
+	}
+	return items, nil
+}
+
+func emojiItems(guild *snapshot.Guild, cfg sdk.BotConfig, _ sdk.GeneratorConfig) ([]templateItem, error) {
+	entities := make([]namedEntity, len(guild.Emojis))
+	for i, emoji := range guild.Emojis {
+		entities[i] = namedEntity{ID: emoji.ID, Name: emoji.Name}
+	}
+	identifiers, err := enumNamer("Emoji", cfg).identifiers(entities)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]templateItem, len(guild.Emojis))
+	for i, emoji := range guild.Emojis {
+		items[i] = templateItem{
+			ID:         emoji.ID,
+			Type:       "emoji",
+			Name:       emoji.Name,
+			Identifier: identifiers[emoji.ID],
+			Mention:    emoji.Mention(),
+			GuildID:    guild.ID,
+			Guild:      guild.Name,
+			Animated:   emoji.Animated,
+			Roles:      emoji.Roles,
+			Discord:    emoji,
+		}
+	}
+	return items, nil
+}
+
+// memberItems returns the members of the guild, only the ones having the role of the config if it has one
+func memberItems(guild *snapshot.Guild, cfg sdk.BotConfig, gen sdk.GeneratorConfig) ([]templateItem, error) {
+	members := utils.Filter(guild.Members, func(member discord.Member) bool {
+		return gen.Role == 0 || slices.Contains(member.RoleIDs, gen.Role)
+	})
+	entities := make([]namedEntity, len(members))
+	for i, member := range members {
+		entities[i] = namedEntity{ID: member.User.ID, Name: member.EffectiveName()}
+	}
+	identifiers, err := enumNamer("Member", cfg).identifiers(entities)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]templateItem, len(members))
+	for i, member := range members {
+		items[i] = templateItem{
+			ID:         member.User.ID,
+			Type:       "member",
+			Name:       member.EffectiveName(),
+			Identifier: identifiers[member.User.ID],
+			Mention:    member.Mention(),
+			GuildID:    guild.ID,
+			Guild:      guild.Name,
+			Roles:      member.RoleIDs,
+			Discord:    member,
+		}
+	}
+	return items, nil
+}
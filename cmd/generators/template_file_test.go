@@ -0,0 +1,46 @@
+package generators
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSortItems(t *testing.T) {
+	// The same member in two guilds has the same ID, with a name in each guild
+	items := []templateItem{
+		{ID: 10, GuildID: 1, Name: "Zoé", Position: 2},
+		{ID: 20, GuildID: 1, Name: "Bob", Position: 10},
+		{ID: 10, GuildID: 2, Name: "Alice", Position: 1},
+	}
+	tests := []struct {
+		name       string
+		sort       string
+		descending bool
+		want       []string
+	}{
+		{"strings", ".Name", false, []string{"Alice", "Bob", "Zoé"}},
+		{"descending", ".Name", true, []string{"Zoé", "Bob", "Alice"}},
+		{"numbers", ".Position", false, []string{"Alice", "Zoé", "Bob"}},
+		{"stable", ".ID", false, []string{"Zoé", "Alice", "Bob"}},
+		{"no sort", "", false, []string{"Zoé", "Bob", "Alice"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sortKey, err := parseExpression("sort", tt.sort, "{{ %s }}")
+			if err != nil {
+				t.Fatal(err)
+			}
+			sorted, err := sortItems(items, sortKey, tt.descending)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var names []string
+			for _, item := range sorted {
+				names = append(names, item.Name)
+			}
+			if !slices.Equal(names, tt.want) {
+				t.Errorf("sorted items = %v, want %v", names, tt.want)
+			}
+		})
+	}
+}
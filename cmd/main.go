@@ -1,21 +1,32 @@
 package main
 
 import (
+	"errors"
 	"flag"
 	"fmt"
 	"log/slog"
+	"maps"
+	"net/http"
 	"os"
+	"slices"
+	"syscall"
 
+	"github.com/bil0u/galaxy-os/cmd/generators"
 	"github.com/bil0u/galaxy-os/sdk"
+	"github.com/bil0u/galaxy-os/sdk/customids"
+	"github.com/bil0u/galaxy-os/sdk/i18n"
+	"github.com/bil0u/galaxy-os/sdk/snapshot"
 	"github.com/bil0u/galaxy-os/sdk/utils"
+	"github.com/disgoorg/disgo/bot"
 	"github.com/disgoorg/disgo/discord"
 )
 
 const defaultConfig = "config.default.toml"
 
 var (
-	version string = "dev"
-	commit  string = "unknown"
+	version   string = "dev"
+	commit    string = "unknown"
+	buildDate string = ""
 )
 
 // Storing flags in a struct
@@ -24,9 +35,14 @@ type CliFlags struct {
 	configFile      string
 	botName         string
 	runAsGenerator  bool
+	checkGenerated  bool
+	fromSnapshot    string
+	snapshotDir     string
 	syncCommands    bool
 	syncRoles       bool
 	logPermissions  bool
+	lintI18n        bool
+	printVersion    bool
 }
 
 func main() {
@@ -38,11 +54,40 @@ func main() {
 	flag.StringVar(&flags.configFile, "use-config", "", "Path to toml configuration file")
 	flag.StringVar(&flags.configDirectory, "config-dir", ".", "Path to the directory in which to find the config file")
 	flag.BoolVar(&flags.runAsGenerator, "generator", false, "Whether to run the bot only in generate mode")
+	flag.BoolVar(&flags.checkGenerated, "check", false, "With -generator, print the diff of outdated generated files instead of writing them, and fail if any")
+	flag.StringVar(&flags.fromSnapshot, "from-snapshot", "", "With -generator, read the guilds from the snapshots of this directory instead of discord")
+	flag.StringVar(&flags.snapshotDir, "snapshot", "", "Save the guilds and bots commands read by the generators as snapshots in this directory, then exit")
 	flag.BoolVar(&flags.syncCommands, "sync-commands", false, "Whether to sync commands to discord")
 	flag.BoolVar(&flags.syncRoles, "sync-roles", false, "Whether to sync bot roles to guilds")
 	flag.BoolVar(&flags.logPermissions, "log-permissions", false, "If true, log bot application permissions")
+	flag.BoolVar(&flags.lintI18n, "lint-i18n", false, "Check translations of all bots commands and catalogs, then exit")
+	flag.BoolVar(&flags.printVersion, "version", false, "Print the build information, then exit")
 	flag.Parse()
 
+	// Print version if needed, no config is required
+	if flags.printVersion {
+		fmt.Print(sdk.ReadBuildInfo(version, commit, buildDate).String())
+		os.Exit(0)
+	}
+
+	// Lint translations if needed, no config is required
+	if flags.lintI18n {
+		if err := lintI18n(); err != nil {
+			slog.Error("Translations check failed", slog.Any("err", err))
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
+	// Save guild snapshots if needed
+	if flags.snapshotDir != "" {
+		if err := takeSnapshots(flags); err != nil {
+			slog.Error("Failed to take snapshots", slog.Any("err", err))
+			os.Exit(-1)
+		}
+		os.Exit(0)
+	}
+
 	// Run bot in generator mode if bot name is "generator"
 	if flags.botName == "generator" {
 		flags.runAsGenerator = true
@@ -51,6 +96,10 @@ func main() {
 	// Run bot in generate mode if needed
 	if flags.runAsGenerator {
 		if err := startGenerator(flags); err != nil {
+			if errors.Is(err, utils.ErrGeneratedDrift) {
+				slog.Error("Generated files have drifted from discord", slog.Any("err", err))
+				os.Exit(1)
+			}
 			slog.Error("Failed to start bot in generate mode", slog.Any("err", err))
 			os.Exit(-1)
 		}
@@ -106,8 +155,13 @@ func startBot(flags CliFlags) error {
 
 	// Setup logger
 	sdk.SetupLogger(config.Log)
+	// Setup components signing key
+	customids.SetSecret(config.Bot.ComponentsSecret)
 	// Create bot
-	b := sdk.NewBot(*config, flags.botName, version, commit)
+	b := sdk.NewBot(*config, flags.botName, sdk.ReadBuildInfo(version, commit, buildDate))
+	b.LoadConfig = func() (*sdk.Config, error) {
+		return getConfig(flags)
+	}
 
 	// Get bot components
 	botParts, err := sdk.GetBotParts(flags.botName)
@@ -134,46 +188,191 @@ func startBot(flags CliFlags) error {
 	}
 	// Log permissions if needed
 	if flags.logPermissions {
-		utils.LogPermissions(b.Client, b.Cfg.Bot.DevGuilds)
+		utils.LogPermissions(b.Client, b.Cfg.Bot.GetGuildsToSync())
+	}
+
+	// Serve health endpoint if needed
+	var healthServer *http.Server
+	if b.Cfg.Health.Address != "" {
+		healthServer = b.ServeHealth(b.Cfg.Health.Address)
 	}
 
-	// Start bot
-	b.Start(botCommands, flags.syncRoles)
+	// Start bot, then replace the process with a new one if a restart was requested
+	restarting := b.Start(botCommands, flags.syncRoles)
+	if healthServer != nil {
+		healthServer.Close()
+	}
+	if restarting {
+		return restart()
+	}
 	return nil
 }
 
+// restart replaces the current process with a new instance of the bot, using the same flags
+func restart() error {
+	executable, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to find executable: %w", err)
+	}
+	return syscall.Exec(executable, os.Args, os.Environ())
+}
+
 // Generator mode
 
-func startGenerator(flags CliFlags) error {
+// getGeneratorConfig returns the configuration of the generators, which read the guilds of the hue bot
+func getGeneratorConfig(flags CliFlags) (*sdk.Config, error) {
 
 	// Defaulting to hue config for generators
 	flags.configFile = "config.hue.toml"
 	config, err := getConfig(flags)
 	if err != nil {
-		return fmt.Errorf("failed to create config: %v", err)
+		return nil, fmt.Errorf("failed to create config: %v", err)
 	}
 
 	config.Log.AddSource = true
 
 	// Setup logger
 	sdk.SetupLogger(config.Log)
+	return config, nil
+}
 
-	slog.Info("Running bot in generator mode...")
-
-	// Get bot components
+// newGeneratorClient creates a client to interact with discord, using the generator bot parts
+func newGeneratorClient(config *sdk.Config) (*bot.Client, error) {
 	botParts, err := sdk.GetBotParts("generator")
 	if err != nil {
-		return err
+		return nil, err
+	}
+	return sdk.NewBotClient(config.Bot.Token, botParts)
+}
+
+// getApplications returns the bots whose registered commands are read by the generators,
+// bots without config are skipped
+func getApplications(flags CliFlags) []snapshot.Application {
+	var applications []snapshot.Application
+	for _, botName := range slices.Sorted(maps.Keys(sdk.GetAllBotParts())) {
+		if botName == "generator" {
+			continue
+		}
+		flags.botName = botName
+		flags.configFile = ""
+		config, err := getConfig(flags)
+		if err != nil {
+			slog.Warn(fmt.Sprintf("Skipping commands of bot '%s'", botName), slog.Any("err", err))
+			continue
+		}
+		applications = append(applications, snapshot.Application{
+			Bot:    botName,
+			ID:     config.Bot.ApplicationID,
+			Token:  config.Bot.Token,
+			Guilds: config.Bot.GetGuildsToSync(),
+		})
 	}
+	return applications
+}
+
+func startGenerator(flags CliFlags) error {
 
-	// Creating client to interact with discord
-	client, err := sdk.NewBotClient(config.Bot.Token, botParts)
+	config, err := getGeneratorConfig(flags)
 	if err != nil {
 		return err
 	}
 
+	slog.Info("Running bot in generator mode...")
+
+	// Register the generators declared in the config
+	for _, gen := range config.Generators {
+		g, err := generators.NewTemplateFileGenerator(gen)
+		if err != nil {
+			return fmt.Errorf("invalid generator in config: %w", err)
+		}
+		utils.RegisterGenerator(g)
+	}
+
+	// Read the guilds from snapshots if needed, from discord otherwise
+	var source snapshot.Source
+	if flags.fromSnapshot != "" {
+		slog.Info(fmt.Sprintf("Reading guilds from the snapshots in '%s'", flags.fromSnapshot))
+		source = snapshot.NewDirSource(flags.fromSnapshot)
+	} else {
+		client, err := newGeneratorClient(config)
+		if err != nil {
+			return err
+		}
+		source = snapshot.NewRestSource(*client, generators.NeedsMembers(config.Generators), getApplications(flags)...)
+	}
+
+	// Only compare the generated files with the ones on disk if needed
+	if flags.checkGenerated {
+		if err := utils.CheckAllGenerators(source, config.Bot, os.Stdout); err != nil {
+			return err
+		}
+		slog.Info("Generated files are up to date")
+		return nil
+	}
+
 	// Run generators
-	utils.RunAllGenerators(*client, config.Bot)
+	utils.RunAllGenerators(source, config.Bot)
 	slog.Info("Complete!")
 	return nil
 }
+
+// Snapshot mode
+
+// takeSnapshots saves the guilds and the bots commands read by the generators in a directory,
+// one file per guild and per bot
+func takeSnapshots(flags CliFlags) error {
+
+	config, err := getGeneratorConfig(flags)
+	if err != nil {
+		return err
+	}
+
+	client, err := newGeneratorClient(config)
+	if err != nil {
+		return err
+	}
+
+	applications := getApplications(flags)
+	source := snapshot.NewRestSource(*client, generators.NeedsMembers(config.Generators), applications...)
+	for _, guildID := range config.Bot.Guilds {
+		guild, err := source.Guild(guildID)
+		if err != nil {
+			return err
+		}
+		if err := snapshot.Save(flags.snapshotDir, guild); err != nil {
+			return fmt.Errorf("failed to save snapshot of guild '%s': %w", guildID, err)
+		}
+		slog.Info(fmt.Sprintf("Saved snapshot of guild '%s' to '%s'", guild.Name, snapshot.Path(flags.snapshotDir, guildID)))
+	}
+	for _, app := range applications {
+		commands, err := source.Commands(app.Bot)
+		if err != nil {
+			return err
+		}
+		if err := snapshot.SaveCommands(flags.snapshotDir, commands); err != nil {
+			return fmt.Errorf("failed to save snapshot of the commands of bot '%s': %w", app.Bot, err)
+		}
+		slog.Info(fmt.Sprintf("Saved snapshot of the commands of bot '%s' to '%s'", app.Bot, snapshot.CommandsPath(flags.snapshotDir, app.Bot)))
+	}
+	return nil
+}
+
+// Translations linter mode
+
+func lintI18n() error {
+	allParts := sdk.GetAllBotParts()
+	var allCommands []discord.ApplicationCommandCreate
+	for _, botName := range slices.Sorted(maps.Keys(allParts)) {
+		allCommands = append(allCommands, allParts[botName].Commands...)
+	}
+
+	issues := i18n.Lint(allCommands)
+	for _, issue := range issues {
+		fmt.Println(issue.String())
+	}
+	if len(issues) > 0 {
+		return fmt.Errorf("found %d translation issues", len(issues))
+	}
+	fmt.Println("No translation issues found")
+	return nil
+}
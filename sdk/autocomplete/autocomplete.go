@@ -0,0 +1,203 @@
+package autocomplete
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/bil0u/galaxy-os/sdk/enums"
+	"github.com/bil0u/galaxy-os/sdk/i18n"
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/handler"
+	"github.com/disgoorg/snowflake/v2"
+)
+
+// maxNameLength is the maximum length of a choice name allowed by discord
+const maxNameLength = 100
+
+// Candidate is a value that can be suggested to the user
+type Candidate struct {
+	// Name is displayed to the user and matched against what they typed
+	Name string
+	// Value is sent back to the command when the candidate is picked
+	Value string
+}
+
+// Provider returns the candidates for an autocomplete interaction, in the locale of the user
+type Provider func(e *handler.AutocompleteEvent) []Candidate
+
+// Handler returns an autocomplete handler suggesting the candidates of the provider
+// that best match the focused option
+func Handler(p Provider) handler.AutocompleteHandler {
+	return func(e *handler.AutocompleteEvent) error {
+		ranked := Rank(Query(e), p(e))
+		choices := make([]discord.AutocompleteChoice, len(ranked))
+		for i, c := range ranked {
+			choices[i] = discord.AutocompleteChoiceString{Name: truncate(c.Name), Value: c.Value}
+		}
+		return e.AutocompleteResult(choices)
+	}
+}
+
+// Query returns what the user typed in the focused option
+func Query(e *handler.AutocompleteEvent) string {
+	raw := e.Data.Focused().Value
+	var query string
+	if err := json.Unmarshal(raw, &query); err != nil {
+		// Numeric options are not quoted
+		return string(raw)
+	}
+	return query
+}
+
+func truncate(name string) string {
+	if utf8.RuneCountInString(name) <= maxNameLength {
+		return name
+	}
+	return string([]rune(name)[:maxNameLength-1]) + "…"
+}
+
+// ByOption dispatches to the provider of the focused option, commands with several
+// autocompleted options can then use a single handler
+func ByOption(providers map[string]Provider) Provider {
+	return func(e *handler.AutocompleteEvent) []Candidate {
+		if p, ok := providers[e.Data.Focused().Name]; ok {
+			return p(e)
+		}
+		return nil
+	}
+}
+
+// Static suggests a fixed list of candidates
+func Static(candidates ...Candidate) Provider {
+	return func(*handler.AutocompleteEvent) []Candidate {
+		return candidates
+	}
+}
+
+// Func suggests the candidates returned by a function of the query, for sources
+// that can filter on their own, e.g. a database or an API
+func Func(fn func(e *handler.AutocompleteEvent, query string) []Candidate) Provider {
+	return func(e *handler.AutocompleteEvent) []Candidate {
+		return fn(e, Query(e))
+	}
+}
+
+// Localized replaces the names of the candidates by their translation in the locale
+// of the user, read from the catalogs under "<prefix>.<value>". Candidates without
+// a translation keep their name
+func Localized(prefix string, p Provider) Provider {
+	// Declare the translations, so the catalogs linter knows they are used
+	for _, key := range i18n.Catalogs()[i18n.DefaultLocale].Keys() {
+		if strings.HasPrefix(key, prefix+".") {
+			i18n.Declare(key)
+		}
+	}
+	return func(e *handler.AutocompleteEvent) []Candidate {
+		candidates := slices.Clone(p(e))
+		for i, c := range candidates {
+			key := prefix + "." + c.Value
+			for _, locale := range i18n.Fallbacks(e.Locale()) {
+				if _, ok := i18n.Lookup(locale, key); ok {
+					candidates[i].Name = i18n.T(e.Locale(), key)
+					break
+				}
+			}
+		}
+		return candidates
+	}
+}
+
+// Roles suggests the generated roles of the guild the interaction comes from, or of every guild
+// outside of guilds. Their value is the role ID
+func Roles() Provider {
+	return func(e *handler.AutocompleteEvent) []Candidate {
+		var candidates []Candidate
+		for _, role := range enums.AllRoles() {
+			if inGuild(e, role.GuildID()) {
+				candidates = append(candidates, Candidate{Name: role.String(), Value: role.ID().String()})
+			}
+		}
+		sortByName(candidates)
+		return candidates
+	}
+}
+
+// Channels suggests the generated channels of the guild the interaction comes from, or of every guild
+// outside of guilds. Their value is the channel ID
+func Channels() Provider {
+	return func(e *handler.AutocompleteEvent) []Candidate {
+		var candidates []Candidate
+		for _, channel := range enums.AllGuildChannels() {
+			if inGuild(e, channel.GuildID()) {
+				candidates = append(candidates, Candidate{Name: channel.String(), Value: channel.ID().String()})
+			}
+		}
+		sortByName(candidates)
+		return candidates
+	}
+}
+
+func inGuild(e *handler.AutocompleteEvent, guildID snowflake.ID) bool {
+	return e.GuildID() == nil || *e.GuildID() == guildID
+}
+
+// CachedRoles suggests the roles of the guild the interaction comes from, read from the cache
+func CachedRoles() Provider {
+	return func(e *handler.AutocompleteEvent) []Candidate {
+		guildID := e.GuildID()
+		if guildID == nil {
+			return nil
+		}
+		var candidates []Candidate
+		e.Client().Caches().RolesForEach(*guildID, func(role discord.Role) {
+			candidates = append(candidates, Candidate{Name: role.Name, Value: role.ID.String()})
+		})
+		sortByName(candidates)
+		return candidates
+	}
+}
+
+// CachedChannels suggests the channels of the guild the interaction comes from, read from the cache.
+// When types are given, only channels of these types are suggested
+func CachedChannels(types ...discord.ChannelType) Provider {
+	return func(e *handler.AutocompleteEvent) []Candidate {
+		guildID := e.GuildID()
+		if guildID == nil {
+			return nil
+		}
+		var candidates []Candidate
+		e.Client().Caches().ChannelsForEach(func(channel discord.GuildChannel) {
+			if channel.GuildID() != *guildID || (len(types) > 0 && !slices.Contains(types, channel.Type())) {
+				return
+			}
+			candidates = append(candidates, Candidate{Name: channel.Name(), Value: channel.ID().String()})
+		})
+		sortByName(candidates)
+		return candidates
+	}
+}
+
+// CachedMembers suggests the members of the guild the interaction comes from, read from the cache
+func CachedMembers() Provider {
+	return func(e *handler.AutocompleteEvent) []Candidate {
+		guildID := e.GuildID()
+		if guildID == nil {
+			return nil
+		}
+		var candidates []Candidate
+		e.Client().Caches().MembersForEach(*guildID, func(member discord.Member) {
+			candidates = append(candidates, Candidate{Name: member.EffectiveName(), Value: member.User.ID.String()})
+		})
+		sortByName(candidates)
+		return candidates
+	}
+}
+
+// sortByName gives map and cache based providers a stable order for equally ranked candidates
+func sortByName(candidates []Candidate) {
+	slices.SortFunc(candidates, func(a, b Candidate) int {
+		return strings.Compare(normalize(a.Name), normalize(b.Name))
+	})
+}
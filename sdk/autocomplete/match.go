@@ -0,0 +1,101 @@
+package autocomplete
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/bil0u/galaxy-os/sdk/utils"
+)
+
+// MaxChoices is the maximum number of choices discord accepts in an autocomplete result
+const MaxChoices = 25
+
+// Scores of the kinds of match, the best kind of match wins
+const (
+	scoreExact      = 1000
+	scorePrefix     = 800
+	scoreWordPrefix = 600
+	scoreSubstring  = 400
+	scoreSubseq     = 200
+)
+
+// normalize makes matching case and accent insensitive
+func normalize(s string) string {
+	return strings.ToLower(strings.TrimSpace(utils.RemoveDiacritics(s)))
+}
+
+// Score rates how well a name matches a query, 0 means it does not match.
+// Matching ignores case and accents, exact matches rank first, then prefixes,
+// word prefixes, substrings and finally names holding the query letters in order
+func Score(query string, name string) int {
+	q, n := normalize(query), normalize(name)
+	switch {
+	case q == "":
+		return 1
+	case n == q:
+		return scoreExact
+	case strings.HasPrefix(n, q):
+		return scorePrefix - (len(n) - len(q))
+	}
+	words := strings.FieldsFunc(n, isSeparator)
+	for i := 1; i < len(words); i++ {
+		if strings.HasPrefix(words[i], q) {
+			return scoreWordPrefix
+		}
+	}
+	if i := strings.Index(n, q); i >= 0 {
+		return scoreSubstring - i
+	}
+	if gaps, ok := subsequenceGaps(q, n); ok {
+		return max(scoreSubseq-gaps, 1)
+	}
+	return 0
+}
+
+func isSeparator(r rune) bool {
+	return unicode.IsSpace(r) || unicode.IsPunct(r)
+}
+
+// subsequenceGaps checks that the runes of q appear in order in n,
+// and returns the number of runes skipped between the first and last match
+func subsequenceGaps(q string, n string) (int, bool) {
+	query := []rune(q)
+	matched, gaps, started := 0, 0, false
+	for _, r := range n {
+		if matched == len(query) {
+			break
+		}
+		if r == query[matched] {
+			matched++
+			started = true
+		} else if started {
+			gaps++
+		}
+	}
+	return gaps, matched == len(query)
+}
+
+// Rank returns the candidates matching the query, best matches first, at most MaxChoices of them.
+// Candidates with the same score keep their order
+func Rank(query string, candidates []Candidate) []Candidate {
+	type scored struct {
+		candidate Candidate
+		score     int
+	}
+	var matches []scored
+	for _, c := range candidates {
+		if score := Score(query, c.Name); score > 0 {
+			matches = append(matches, scored{candidate: c, score: score})
+		}
+	}
+	slices.SortStableFunc(matches, func(a, b scored) int {
+		return b.score - a.score
+	})
+
+	ranked := make([]Candidate, 0, min(len(matches), MaxChoices))
+	for _, m := range matches[:min(len(matches), MaxChoices)] {
+		ranked = append(ranked, m.candidate)
+	}
+	return ranked
+}
@@ -0,0 +1,92 @@
+package autocomplete
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+)
+
+func TestScore(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		value string
+		want  int
+	}{
+		{"empty query", "", "General", 1},
+		{"blank query", "  ", "General", 1},
+		{"exact", "general", "General", scoreExact},
+		{"exact without accents", "general", "Général", scoreExact},
+		{"exact with accents in the query", "GÉNÉRAL", "general", scoreExact},
+		{"prefix", "gen", "General", scorePrefix - 4},
+		{"shorter prefix ranks lower", "gen", "Generalement", scorePrefix - 9},
+		{"prefix with spaces around the query", "  gen ", "General", scorePrefix - 4},
+		{"word prefix", "cap", "Le Capitaine", scoreWordPrefix},
+		{"word prefix after an apostrophe", "equipage", "d’équipage", scoreWordPrefix},
+		{"substring", "pit", "Capitaine", scoreSubstring - 2},
+		{"subsequence", "cpt", "Capitaine", scoreSubseq - 2},
+		{"no match", "xyz", "Capitaine", 0},
+		{"letters out of order", "tpc", "Capitaine", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Score(tt.query, tt.value); got != tt.want {
+				t.Errorf("Score(%q, %q) = %d, want %d", tt.query, tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func names(candidates []Candidate) []string {
+	result := make([]string, len(candidates))
+	for i, c := range candidates {
+		result[i] = c.Name
+	}
+	return result
+}
+
+func TestRank(t *testing.T) {
+	var candidates []Candidate
+	for _, name := range []string{"Green", "Xgen", "Other", "Le gendarme", "Agent", "Général", "gen"} {
+		candidates = append(candidates, Candidate{Name: name, Value: name})
+	}
+
+	tests := []struct {
+		name  string
+		query string
+		want  []string
+	}{
+		// Exact, prefix, word prefix, substrings keeping their order, then subsequence
+		{"tiers", "gen", []string{"gen", "Général", "Le gendarme", "Xgen", "Agent", "Green"}},
+		{"empty query keeps the order", "", []string{"Green", "Xgen", "Other", "Le gendarme", "Agent", "Général", "gen"}},
+		{"no match", "zzz", []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := names(Rank(tt.query, candidates)); !slices.Equal(got, tt.want) {
+				t.Errorf("Rank(%q) = %v, want %v", tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRankMaxChoices(t *testing.T) {
+	var candidates []Candidate
+	for i := range MaxChoices + 10 {
+		name := fmt.Sprintf("role %d", i)
+		candidates = append(candidates, Candidate{Name: name, Value: name})
+	}
+	// The exact match is the last candidate, it must still be kept
+	candidates = append(candidates, Candidate{Name: "role", Value: "role"})
+
+	ranked := Rank("role", candidates)
+	if len(ranked) != MaxChoices {
+		t.Fatalf("Rank returned %d choices, want %d", len(ranked), MaxChoices)
+	}
+	if ranked[0].Name != "role" {
+		t.Errorf("first choice is %q, want the exact match", ranked[0].Name)
+	}
+	if want := fmt.Sprintf("role %d", MaxChoices-2); ranked[MaxChoices-1].Name != want {
+		t.Errorf("last choice is %q, want %q", ranked[MaxChoices-1].Name, want)
+	}
+}
@@ -6,46 +6,95 @@ import (
 	"log/slog"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
 	"time"
 
+	"github.com/bil0u/galaxy-os/sdk/help"
+	"github.com/bil0u/galaxy-os/sdk/pages"
 	"github.com/disgoorg/disgo/bot"
 	"github.com/disgoorg/disgo/discord"
 	"github.com/disgoorg/disgo/events"
 	"github.com/disgoorg/disgo/gateway"
-	"github.com/disgoorg/disgo/handler"
 	"github.com/disgoorg/paginator"
 )
 
-func NewBot(cfg Config, name string, version string, commit string) *Bot {
+func NewBot(cfg Config, name string, build BuildInfo) *Bot {
 	return &Bot{
 		Cfg:       cfg,
 		Name:      name,
-		Version:   version,
-		Commit:    commit,
-		Paginator: paginator.New(),
+		Build:     build,
+		Paginator: paginator.New(pages.ManagerOptions()...),
+		Help:      help.NewIndex(),
 		Client:    nil,
+		StartedAt: time.Now(),
+		stop:      make(chan struct{}),
 	}
 }
 
 type Bot struct {
 	Name      string
-	Version   string
-	Commit    string
+	Build     BuildInfo
 	Cfg       Config
 	Client    bot.Client
 	Paginator *paginator.Manager
+	// Help holds the help metadata of the commands, described when creating the router
+	Help      *help.Index
+	StartedAt time.Time
+	// LoadConfig reads the configuration again from its source, it is used to reload it at runtime
+	LoadConfig func() (*Config, error)
+
+	parts      BotParts
+	cfgMu      sync.RWMutex
+	stop       chan struct{}
+	stopOnce   sync.Once
+	restarting bool
+}
+
+// Config returns the current configuration, safe to use while it is being reloaded
+func (b *Bot) Config() Config {
+	b.cfgMu.RLock()
+	defer b.cfgMu.RUnlock()
+	return b.Cfg
+}
+
+// ReloadConfig reads the configuration again and applies the log level.
+// The token and the components secret are only read at startup
+func (b *Bot) ReloadConfig() error {
+	if b.LoadConfig == nil {
+		return fmt.Errorf("bot '%s' has no config loader", b.Name)
+	}
+	cfg, err := b.LoadConfig()
+	if err != nil {
+		return err
+	}
+	b.cfgMu.Lock()
+	b.Cfg = *cfg
+	b.cfgMu.Unlock()
+	SetLogLevel(cfg.Log.MinLevel())
+	slog.Info("Configuration reloaded", slog.String("bot", b.Name))
+	return nil
+}
+
+// Restart stops the bot, Start then returns true so that the process can start again
+func (b *Bot) Restart() {
+	b.stopOnce.Do(func() {
+		b.restarting = true
+		close(b.stop)
+	})
 }
 
 // SetupBot sets up the bot with the provided parts
 func (b *Bot) SetupBot(parts BotParts) error {
+	b.parts = parts
 
 	// Add default listeners
-	b.Client.AddEventListeners(b.Paginator)
+	b.Client.AddEventListeners(pages.Listener(b.Paginator))
 	b.Client.AddEventListeners(bot.NewListenerFunc(b.OnReady))
 
-	// Create router and register it as an event listener
+	// Create router and register it as an event listener, along with the jump to page of the paginated lists
 	router := parts.CreateRouter(b)
+	pages.Register(router, b.Paginator)
 	b.Client.AddEventListeners(router)
 
 	// Create bot listeners
@@ -55,7 +104,9 @@ func (b *Bot) SetupBot(parts BotParts) error {
 	return nil
 }
 
-func (b *Bot) Start(syncCommands []discord.ApplicationCommandCreate, syncRoles bool) {
+// Start opens the gateway and blocks until the bot is stopped by a signal or restarted.
+// It returns true if the bot should be restarted
+func (b *Bot) Start(syncCommands []discord.ApplicationCommandCreate, syncRoles bool) bool {
 
 	slog.Info(fmt.Sprintf("Starting bot '%s' ...", b.Name))
 
@@ -69,28 +120,14 @@ func (b *Bot) Start(syncCommands []discord.ApplicationCommandCreate, syncRoles b
 
 	// Sync roles if needed
 	if syncRoles {
-		for _, guildID := range b.Cfg.Bot.GetGuildsToSync() {
-			guildRoles := b.Cfg.Bot.GetGuildRoles(guildID)
-			slog.Info(fmt.Sprintf("Syncing roles for guild '%s'", guildID), slog.Any("roles", guildRoles))
-			guild := b.Client.Rest()
-
-			for _, roleID := range guildRoles {
-				// Assign each role to the bot
-				err := guild.AddMemberRole(guildID, b.Client.ApplicationID(), roleID)
-				if err != nil {
-					slog.Error("Failed to assign role '%s' to bot:", roleID.String(), slog.Any("err", err))
-				} else {
-					slog.Info("Successfully assigned role '%s' to bot in guild '%s'", roleID.String(), guildID.String())
-				}
-			}
+		if err := b.SyncRoles(); err != nil {
+			slog.Error("Failed to sync roles", slog.Any("err", err))
 		}
 	}
 
 	// Sync commands if needed
 	if syncCommands != nil {
-		guilds := b.Cfg.Bot.GetGuildsToSync()
-		slog.Info("Syncing commands", slog.Any("guilds", guilds))
-		if err := handler.SyncCommands(b.Client, syncCommands, guilds); err != nil {
+		if err := b.SyncCommands(syncCommands); err != nil {
 			slog.Error("Failed to sync commands", slog.Any("err", err))
 		}
 	}
@@ -104,12 +141,17 @@ func (b *Bot) Start(syncCommands []discord.ApplicationCommandCreate, syncRoles b
 		os.Exit(-1)
 	}
 
-	// Wait for signal to shutdown
+	// Wait for signal to shutdown, or for a restart
 	slog.Info("Bot is running. Press CTRL-C to exit.")
 	s := make(chan os.Signal, 1)
 	signal.Notify(s, syscall.SIGINT, syscall.SIGTERM)
-	<-s
-	slog.Info("Shutting down bot...")
+	select {
+	case <-s:
+		slog.Info("Shutting down bot...")
+	case <-b.stop:
+		slog.Info("Restarting bot...")
+	}
+	return b.restarting
 }
 
 func (b *Bot) OnReady(_ *events.Ready) {
@@ -2,6 +2,7 @@ package sdk
 
 import (
 	"fmt"
+	"maps"
 
 	"github.com/disgoorg/disgo"
 	"github.com/disgoorg/disgo/bot"
@@ -63,6 +64,11 @@ func GetBotParts(botName string) (BotParts, error) {
 	return parts, nil
 }
 
+// GetAllBotParts returns the bot parts of every registered bot, keyed by bot name
+func GetAllBotParts() map[string]BotParts {
+	return maps.Clone(partsRegistry)
+}
+
 func (p *BotParts) AddIntents(intents ...gateway.Intents) *BotParts {
 	p.Intents = append(p.Intents, intents...)
 	return p
@@ -0,0 +1,325 @@
+package commands
+
+import (
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/bil0u/galaxy-os/sdk"
+	"github.com/bil0u/galaxy-os/sdk/guards"
+	"github.com/bil0u/galaxy-os/sdk/help"
+	"github.com/bil0u/galaxy-os/sdk/i18n"
+	"github.com/bil0u/galaxy-os/sdk/pages"
+	"github.com/bil0u/galaxy-os/sdk/slash"
+	"github.com/bil0u/galaxy-os/sdk/utils"
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/handler"
+	"github.com/disgoorg/disgo/rest"
+)
+
+type AdminNoOptions struct{}
+
+type AdminLogLevelOptions struct {
+	Level string `name:"level" required:"true" choices:"debug,info,warn,error"`
+}
+
+var (
+	adminConfigReloaded  = i18n.Declare("commands.admin.responses.config_reloaded")
+	adminLogLevelSet     = i18n.Declare("commands.admin.responses.log_level_set")
+	adminCommandsSynced  = i18n.Declare("commands.admin.responses.commands_synced")
+	adminPlanUpToDate    = i18n.Declare("commands.admin.responses.plan.up_to_date")
+	adminPlanGuild       = i18n.Declare("commands.admin.responses.plan.guild")
+	adminPlanCreate      = i18n.Declare("commands.admin.responses.plan.create")
+	adminPlanUpdate      = i18n.Declare("commands.admin.responses.plan.update")
+	adminPlanDelete      = i18n.Declare("commands.admin.responses.plan.delete")
+	adminRolesSynced     = i18n.Declare("commands.admin.responses.roles_synced")
+	adminAuditTitle      = i18n.Declare("commands.admin.responses.audit.title")
+	adminAuditGuild      = i18n.Declare("commands.admin.responses.audit.guild")
+	adminAuditRoles      = i18n.Declare("commands.admin.responses.audit.roles")
+	adminAuditUser       = i18n.Declare("commands.admin.responses.audit.user")
+	adminAuditOverwrite  = i18n.Declare("commands.admin.responses.audit.overwrite")
+	adminAuditFailed     = i18n.Declare("commands.admin.responses.audit.failed")
+	adminStatusUptime    = i18n.Declare("commands.admin.responses.status.uptime")
+	adminStatusVersion   = i18n.Declare("commands.admin.responses.status.version")
+	adminStatusLatency   = i18n.Declare("commands.admin.responses.status.latency")
+	adminStatusLogLevel  = i18n.Declare("commands.admin.responses.status.log_level")
+	adminStatusCache     = i18n.Declare("commands.admin.responses.status.cache")
+	adminStatusCacheLine = i18n.Declare("commands.admin.responses.status.cache_counts")
+	adminRestarting      = i18n.Declare("commands.admin.responses.restarting")
+	adminExamples        = i18n.Declare("commands.admin.examples")
+)
+
+var adminPermissions = discord.PermissionAdministrator
+
+func newAdminTree() *slash.Tree {
+	tree := slash.NewTree("commands.admin",
+		slash.Group("config",
+			slash.Sub[AdminNoOptions]("reload"),
+			slash.Sub[AdminLogLevelOptions]("log-level"),
+		),
+		slash.Group("commands",
+			slash.Sub[AdminNoOptions]("sync"),
+			slash.Sub[AdminNoOptions]("plan"),
+		),
+		slash.Group("roles",
+			slash.Sub[AdminNoOptions]("sync"),
+		),
+		slash.Group("permissions",
+			slash.Sub[AdminNoOptions]("audit"),
+		),
+		slash.Sub[AdminNoOptions]("status"),
+		slash.Sub[AdminNoOptions]("restart"),
+	)
+	tree.DefaultMemberPermissions = &adminPermissions
+	return tree
+}
+
+// Admin is the /admin command, restricted to the roles configured in admin_roles
+var Admin = newAdminTree()
+
+// RegisterAdmin adds the routes of the /admin command to the router, behind the admin roles guard.
+// The handlers are bound to the Admin tree, so that the command synced to discord is the one verified
+func RegisterAdmin(b *sdk.Bot, r handler.Router) {
+	tree := Admin
+	slash.Handle(tree, "/admin/config/reload", adminReloadConfig(b))
+	slash.Handle(tree, "/admin/config/log-level", adminSetLogLevel)
+	slash.Handle(tree, "/admin/commands/sync", adminSyncCommands(b))
+	slash.Handle(tree, "/admin/commands/plan", adminPlanCommands(b))
+	slash.Handle(tree, "/admin/roles/sync", adminSyncRoles(b))
+	slash.Handle(tree, "/admin/permissions/audit", adminAuditPermissions(b))
+	slash.Handle(tree, "/admin/status", adminStatus(b))
+	slash.Handle(tree, "/admin/restart", adminRestart(b))
+
+	// The admin roles are read on each use, so that reloading the config updates them
+	adminGuards := []guards.Guard{guards.GuildOnly(), func(e *handler.InteractionEvent) *guards.Denial {
+		return guards.HasAnyRoleID(b.Config().Bot.AdminRoles...)(e)
+	}}
+	r.Group(func(r handler.Router) {
+		r.Use(guards.Require(adminGuards...))
+		tree.MustRegister(r)
+	})
+	b.Help.Describe(help.Meta{Module: ModuleAdmin, Examples: adminExamples, Guards: adminGuards}, "/admin")
+}
+
+func adminReply(e *handler.CommandEvent, content string) error {
+	return e.CreateMessage(discord.MessageCreate{
+		Content: content,
+		Flags:   discord.MessageFlagEphemeral,
+	})
+}
+
+// adminDeferred runs a slow operation after telling discord the bot is thinking,
+// the response is then replaced by the returned content
+func adminDeferred(e *handler.CommandEvent, run func() (string, error)) error {
+	if err := e.DeferCreateMessage(true); err != nil {
+		return err
+	}
+	content, err := run()
+	if err != nil {
+		return err
+	}
+	_, err = e.UpdateInteractionResponse(discord.MessageUpdate{Content: &content})
+	return err
+}
+
+// adminDeferredList is like adminDeferred, for slow operations whose result is a paginated list
+func adminDeferredList(b *sdk.Bot, e *handler.CommandEvent, run func() (pages.List[string], error)) error {
+	if err := e.DeferCreateMessage(true); err != nil {
+		return err
+	}
+	list, err := run()
+	if err != nil {
+		return err
+	}
+	return pages.Respond(b.Paginator, deferredResponder{e}, list)
+}
+
+// deferredResponder creates messages by replacing the response of a deferred command
+type deferredResponder struct {
+	*handler.CommandEvent
+}
+
+func (r deferredResponder) CreateMessage(message discord.MessageCreate, opts ...rest.RequestOpt) error {
+	update := discord.MessageUpdate{Embeds: &message.Embeds}
+	if len(message.Components) > 0 {
+		update.Components = &message.Components
+	}
+	_, err := r.UpdateInteractionResponse(update, opts...)
+	return err
+}
+
+func adminReloadConfig(b *sdk.Bot) func(*handler.CommandEvent, AdminNoOptions) error {
+	return func(e *handler.CommandEvent, _ AdminNoOptions) error {
+		if err := b.ReloadConfig(); err != nil {
+			return err
+		}
+		return adminReply(e, adminConfigReloaded.T(e.Locale()))
+	}
+}
+
+func adminSetLogLevel(e *handler.CommandEvent, options AdminLogLevelOptions) error {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(options.Level)); err != nil {
+		return err
+	}
+	sdk.SetLogLevel(level)
+	return adminReply(e, adminLogLevelSet.T(e.Locale(), i18n.Vars{"level": level.String()}))
+}
+
+func adminSyncCommands(b *sdk.Bot) func(*handler.CommandEvent, AdminNoOptions) error {
+	return func(e *handler.CommandEvent, _ AdminNoOptions) error {
+		parts, err := sdk.GetBotParts(b.Name)
+		if err != nil {
+			return err
+		}
+		return adminDeferred(e, func() (string, error) {
+			if err := b.SyncCommands(parts.Commands); err != nil {
+				return "", err
+			}
+			return adminCommandsSynced.T(e.Locale(), i18n.Vars{i18n.CountVar: len(parts.Commands)}), nil
+		})
+	}
+}
+
+func adminPlanCommands(b *sdk.Bot) func(*handler.CommandEvent, AdminNoOptions) error {
+	return func(e *handler.CommandEvent, _ AdminNoOptions) error {
+		parts, err := sdk.GetBotParts(b.Name)
+		if err != nil {
+			return err
+		}
+		return adminDeferred(e, func() (string, error) {
+			plans, err := b.PlanCommands(parts.Commands)
+			if err != nil {
+				return "", err
+			}
+			locale := e.Locale()
+			var lines []string
+			for _, plan := range plans {
+				if plan.IsEmpty() {
+					continue
+				}
+				lines = append(lines, adminPlanGuild.T(locale, i18n.Vars{"guild": plan.GuildID}))
+				for _, change := range []struct {
+					label i18n.Key
+					names []string
+				}{{adminPlanCreate, plan.Create}, {adminPlanUpdate, plan.Update}, {adminPlanDelete, plan.Delete}} {
+					if len(change.names) > 0 {
+						lines = append(lines, fmt.Sprintf("- %s: `%s`", change.label.T(locale), strings.Join(change.names, "`, `")))
+					}
+				}
+			}
+			if len(lines) == 0 {
+				return adminPlanUpToDate.T(locale), nil
+			}
+			return strings.Join(lines, "\n"), nil
+		})
+	}
+}
+
+func adminSyncRoles(b *sdk.Bot) func(*handler.CommandEvent, AdminNoOptions) error {
+	return func(e *handler.CommandEvent, _ AdminNoOptions) error {
+		return adminDeferred(e, func() (string, error) {
+			if err := b.SyncRoles(); err != nil {
+				return "", err
+			}
+			return adminRolesSynced.T(e.Locale()), nil
+		})
+	}
+}
+
+func adminAuditPermissions(b *sdk.Bot) func(*handler.CommandEvent, AdminNoOptions) error {
+	return func(e *handler.CommandEvent, _ AdminNoOptions) error {
+		return adminDeferredList(b, e, func() (pages.List[string], error) {
+			return adminAuditList(b, e.Locale()), nil
+		})
+	}
+}
+
+// adminAuditList lists the permissions of the bot in each guild it syncs its commands to, one request per guild
+func adminAuditList(b *sdk.Bot, locale discord.Locale) pages.List[string] {
+	var lines []string
+	for _, guildID := range b.Config().Bot.GetGuildsToSync() {
+		lines = append(lines, adminAuditGuild.T(locale, i18n.Vars{"guild": guildID}))
+		rolePerms, userPerms, channelOverwrites, err := utils.CheckBotPermissions(b.Client, guildID)
+		if err != nil {
+			slog.Error("Error checking bot permissions", slog.Any("err", err))
+			lines = append(lines, adminAuditFailed.T(locale))
+			continue
+		}
+		lines = append(lines,
+			adminAuditRoles.T(locale, i18n.Vars{"permissions": rolePerms.String()}),
+			adminAuditUser.T(locale, i18n.Vars{"permissions": userPerms.String()}),
+		)
+		channelIDs := slices.Sorted(maps.Keys(channelOverwrites))
+		for _, channelID := range channelIDs {
+			for _, overwrite := range channelOverwrites[channelID] {
+				var target string
+				var allow, deny discord.Permissions
+				switch o := overwrite.(type) {
+				case discord.RolePermissionOverwrite:
+					target, allow, deny = discord.RoleMention(o.RoleID), o.Allow, o.Deny
+				case discord.MemberPermissionOverwrite:
+					target, allow, deny = discord.UserMention(o.UserID), o.Allow, o.Deny
+				default:
+					continue
+				}
+				lines = append(lines, adminAuditOverwrite.T(locale, i18n.Vars{
+					"channel": discord.ChannelMention(channelID),
+					"target":  target,
+					"allow":   allow.String(),
+					"deny":    deny.String(),
+				}))
+			}
+		}
+	}
+	return pages.List[string]{
+		Title:     adminAuditTitle.T(locale),
+		Items:     lines,
+		PerPage:   15,
+		Render:    func(_ discord.Locale, line string) string { return line },
+		Jump:      true,
+		Ephemeral: true,
+	}
+}
+
+func adminStatus(b *sdk.Bot) func(*handler.CommandEvent, AdminNoOptions) error {
+	return func(e *handler.CommandEvent, _ AdminNoOptions) error {
+		locale := e.Locale()
+		caches := b.Client.Caches()
+		latency := "-"
+		if b.Client.HasGateway() {
+			latency = b.Client.Gateway().Latency().String()
+		}
+		embed := discord.NewEmbedBuilder().
+			SetTitle(b.Name).
+			AddField(adminStatusUptime.T(locale), discord.FormattedTimestampMention(b.StartedAt.Unix(), discord.TimestampStyleRelative), true).
+			AddField(adminStatusVersion.T(locale), fmt.Sprintf("`%s` (`%s`)", b.Build.Version, b.Build.Commit), true).
+			AddField(adminStatusLatency.T(locale), latency, true).
+			AddField(adminStatusLogLevel.T(locale), sdk.LogLevel().String(), true).
+			AddField(adminStatusCache.T(locale), adminStatusCacheLine.T(locale, i18n.Vars{
+				"guilds":   strconv.Itoa(caches.GuildsLen()),
+				"channels": strconv.Itoa(caches.ChannelsLen()),
+				"roles":    strconv.Itoa(caches.RolesAllLen()),
+				"members":  strconv.Itoa(caches.MembersAllLen()),
+			}), false).
+			Build()
+		return e.CreateMessage(discord.MessageCreate{
+			Embeds: []discord.Embed{embed},
+			Flags:  discord.MessageFlagEphemeral,
+		})
+	}
+}
+
+func adminRestart(b *sdk.Bot) func(*handler.CommandEvent, AdminNoOptions) error {
+	return func(e *handler.CommandEvent, _ AdminNoOptions) error {
+		if err := adminReply(e, adminRestarting.T(e.Locale())); err != nil {
+			return err
+		}
+		slog.Warn("Restart requested", slog.String("audit", "admin_restart"), slog.String("user_id", e.User().ID.String()))
+		b.Restart()
+		return nil
+	}
+}
@@ -0,0 +1,70 @@
+package commands
+
+import (
+	"github.com/bil0u/galaxy-os/sdk"
+	"github.com/bil0u/galaxy-os/sdk/i18n"
+	"github.com/bil0u/galaxy-os/sdk/pages"
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/events"
+	"github.com/disgoorg/disgo/handler"
+)
+
+var (
+	helpName        = i18n.Declare("commands.help.name")
+	helpDescription = i18n.Declare("commands.help.description")
+	helpTitle       = i18n.Declare("commands.help.responses.title")
+)
+
+// Modules group the commands in /help
+var (
+	ModuleGeneral = i18n.Declare("commands.help.modules.general")
+	ModuleLab     = i18n.Declare("commands.help.modules.lab")
+	ModuleAdmin   = i18n.Declare("commands.help.modules.admin")
+)
+
+var Help = discord.SlashCommandCreate{
+	Name:                     helpName.Default(),
+	NameLocalizations:        helpName.Localizations(),
+	Description:              helpDescription.Default(),
+	DescriptionLocalizations: helpDescription.Localizations(),
+}
+
+// CreateHelpHandler lists the commands of the bot the user can use, grouped by module
+func CreateHelpHandler(b *sdk.Bot) handler.CommandHandler {
+	return func(e *handler.CommandEvent) error {
+		parts, err := sdk.GetBotParts(b.Name)
+		if err != nil {
+			return err
+		}
+		visible, err := b.Help.Visible(interactionEvent(e), parts.Commands)
+		if err != nil {
+			return err
+		}
+		locale := e.Locale()
+		lines, err := b.Help.Lines(locale, visible)
+		if err != nil {
+			return err
+		}
+		return pages.Respond(b.Paginator, e, pages.List[string]{
+			Title:     helpTitle.T(locale, i18n.Vars{"bot": b.Name}),
+			Items:     lines,
+			PerPage:   12,
+			Render:    func(_ discord.Locale, line string) string { return line },
+			Jump:      true,
+			Ephemeral: true,
+		})
+	}
+}
+
+// interactionEvent returns the command event as the generic event the guards check
+func interactionEvent(e *handler.CommandEvent) *handler.InteractionEvent {
+	return &handler.InteractionEvent{
+		InteractionCreate: &events.InteractionCreate{
+			GenericEvent: e.GenericEvent,
+			Interaction:  e.ApplicationCommandInteraction,
+			Respond:      e.Respond,
+		},
+		Vars: e.Vars,
+		Ctx:  e.Ctx,
+	}
+}
@@ -0,0 +1,49 @@
+package commands
+
+import (
+	"strings"
+
+	"github.com/bil0u/galaxy-os/sdk/i18n"
+	"github.com/bil0u/galaxy-os/sdk/menus"
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/handler"
+)
+
+var (
+	memberInfoCreatedAt = i18n.Declare("commands.member_info.responses.created_at")
+	memberInfoJoinedAt  = i18n.Declare("commands.member_info.responses.joined_at")
+	memberInfoRoles     = i18n.Declare("commands.member_info.responses.roles")
+	memberInfoNoRoles   = i18n.Declare("commands.member_info.responses.no_roles")
+)
+
+var MemberInfo = menus.UserCommand{
+	Key:     "commands.member_info",
+	Handler: MemberInfoHandler,
+}
+
+func MemberInfoHandler(e *handler.CommandEvent, target menus.UserTarget) error {
+	locale := e.Locale()
+	embed := discord.NewEmbedBuilder().
+		SetTitle(target.User.EffectiveName()).
+		SetThumbnail(target.User.EffectiveAvatarURL()).
+		AddField(memberInfoCreatedAt.T(locale), discord.FormattedTimestampMention(target.User.CreatedAt().Unix(), discord.TimestampStyleLongDate), true)
+
+	if target.Member != nil {
+		embed.AddField(memberInfoJoinedAt.T(locale), discord.FormattedTimestampMention(target.Member.JoinedAt.Unix(), discord.TimestampStyleLongDate), true)
+
+		roles := memberInfoNoRoles.T(locale)
+		if len(target.Member.RoleIDs) > 0 {
+			mentions := make([]string, len(target.Member.RoleIDs))
+			for i, roleID := range target.Member.RoleIDs {
+				mentions[i] = discord.RoleMention(roleID)
+			}
+			roles = strings.Join(mentions, " ")
+		}
+		embed.AddField(memberInfoRoles.T(locale), roles, false)
+	}
+
+	return e.CreateMessage(discord.MessageCreate{
+		Embeds: []discord.Embed{embed.Build()},
+		Flags:  discord.MessageFlagEphemeral,
+	})
+}
@@ -1,66 +1,48 @@
 package commands
 
 import (
-	"github.com/bil0u/galaxy-os/sdk/utils"
+	"github.com/bil0u/galaxy-os/sdk/autocomplete"
+	"github.com/bil0u/galaxy-os/sdk/components"
+	"github.com/bil0u/galaxy-os/sdk/i18n"
+	"github.com/bil0u/galaxy-os/sdk/slash"
 	"github.com/disgoorg/disgo/discord"
 	"github.com/disgoorg/disgo/handler"
 )
 
-var Test = discord.SlashCommandCreate{
-	Name: "test",
-	NameLocalizations: utils.LocalizedString{
-		discord.LocaleEnglishUS: "test",
-		discord.LocaleFrench:    "test",
-	},
-	Description: "Test command",
-	DescriptionLocalizations: utils.LocalizedString{
-		discord.LocaleEnglishUS: "Test command",
-		discord.LocaleFrench:    "Commande de test",
-	},
-	Options: []discord.ApplicationCommandOption{
-		discord.ApplicationCommandOptionString{
-			Name: "choice",
-			NameLocalizations: utils.LocalizedString{
-				discord.LocaleEnglishUS: "choice",
-				discord.LocaleFrench:    "choix",
-			},
-			Description: "Select a number",
-			DescriptionLocalizations: utils.LocalizedString{
-				discord.LocaleEnglishUS: "Select a number",
-				discord.LocaleFrench:    "Selectionne un nombre",
-			},
-			Required:     true,
-			Autocomplete: true,
-		},
-	},
+type TestOptions struct {
+	// Choice is carried by the custom ID of the test button, its length is bounded so that
+	// the custom ID stays under the discord limit, even with escaped or multi-byte characters
+	Choice string `name:"choice" required:"true" autocomplete:"true" max:"15"`
 }
 
-func TestHandler(e *handler.CommandEvent) error {
-	data := e.SlashCommandInteractionData()
+var Test = slash.Command[TestOptions]{
+	Key:     "commands.test",
+	Handler: TestHandler,
+}
+
+var (
+	testChoiceMessage = i18n.Declare("commands.test.responses.choice")
+	TestExamples      = i18n.Declare("commands.test.examples")
+)
+
+func TestHandler(e *handler.CommandEvent, options TestOptions) error {
+	buttonID, err := components.TestButton.Encode(components.TestButtonState{Choice: options.Choice})
+	if err != nil {
+		return err
+	}
 	return e.CreateMessage(discord.NewMessageCreateBuilder().
-		SetContentf(utils.LocalizedString{
-			discord.LocaleEnglishUS: "Test command. Choice: %s",
-			discord.LocaleFrench:    "Commande de test. Choix: %s",
-		}[e.Locale()], data.String("choice")).
-		// SetContentf, data.String("choice")).
-		AddActionRow(discord.NewPrimaryButton("test", "/test-button")).
+		SetContent(testChoiceMessage.T(e.Locale(), i18n.Vars{"choice": options.Choice})).
+		AddActionRow(
+			discord.NewPrimaryButton("test", buttonID),
+			discord.NewSecondaryButton("form", "/test-form-button"),
+		).
 		Build(),
 	)
 }
 
-func TestAutocompleteHandler(e *handler.AutocompleteEvent) error {
-	return e.AutocompleteResult([]discord.AutocompleteChoice{
-		discord.AutocompleteChoiceString{
-			Name:  "1",
-			Value: "1",
-		},
-		discord.AutocompleteChoiceString{
-			Name:  "2",
-			Value: "2",
-		},
-		discord.AutocompleteChoiceString{
-			Name:  "3",
-			Value: "3",
-		},
-	})
-}
+// TestAutocompleteHandler suggests numbers, displayed in the locale of the user
+var TestAutocompleteHandler = autocomplete.Handler(autocomplete.Localized("commands.test.suggestions", autocomplete.Static(
+	autocomplete.Candidate{Name: "1", Value: "1"},
+	autocomplete.Candidate{Name: "2", Value: "2"},
+	autocomplete.Candidate{Name: "3", Value: "3"},
+)))
@@ -0,0 +1,26 @@
+package commands
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/bil0u/galaxy-os/sdk/components"
+)
+
+// TestTestButtonFitsChoice makes sure the longest choice accepted by /test fits in the custom ID of the test button
+func TestTestButtonFitsChoice(t *testing.T) {
+	field, _ := reflect.TypeFor[TestOptions]().FieldByName("Choice")
+	maxLength, err := strconv.Atoi(field.Tag.Get("max"))
+	if err != nil {
+		t.Fatalf("choice has no max length: %v", err)
+	}
+	// Dots are escaped on two characters, emojis are four bytes long
+	for _, char := range []string{"a", ".", "é", "✨", "🚀"} {
+		choice := strings.Repeat(char, maxLength)
+		if _, err := components.TestButton.Encode(components.TestButtonState{Choice: choice}); err != nil {
+			t.Errorf("choice of %d '%s' doesn't fit: %v", maxLength, char, err)
+		}
+	}
+}
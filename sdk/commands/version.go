@@ -2,30 +2,87 @@ package commands
 
 import (
 	"fmt"
+	"strings"
+	"unicode/utf8"
 
 	"github.com/bil0u/galaxy-os/sdk"
-	"github.com/bil0u/galaxy-os/sdk/utils"
+	"github.com/bil0u/galaxy-os/sdk/i18n"
 	"github.com/disgoorg/disgo/discord"
 	"github.com/disgoorg/disgo/handler"
 )
 
+var (
+	versionName        = i18n.Declare("commands.version.name")
+	versionDescription = i18n.Declare("commands.version.description")
+	versionVersion     = i18n.Declare("commands.version.responses.version")
+	versionCommit      = i18n.Declare("commands.version.responses.commit")
+	versionBuildDate   = i18n.Declare("commands.version.responses.build_date")
+	versionGo          = i18n.Declare("commands.version.responses.go")
+	versionUptime      = i18n.Declare("commands.version.responses.uptime")
+	versionLatency     = i18n.Declare("commands.version.responses.latency")
+	versionShard       = i18n.Declare("commands.version.responses.shard")
+	versionGuilds      = i18n.Declare("commands.version.responses.guilds")
+	versionCommands    = i18n.Declare("commands.version.responses.commands")
+	versionModules     = i18n.Declare("commands.version.responses.modules")
+	versionUnknown     = i18n.Declare("commands.version.responses.unknown")
+)
+
+// maxFieldLength is the maximum length of an embed field value allowed by discord
+const maxFieldLength = 1024
+
 var Version = discord.SlashCommandCreate{
-	Name: "version",
-	NameLocalizations: utils.LocalizedString{
-		discord.LocaleEnglishUS: "version",
-		discord.LocaleFrench:    "version",
-	},
-	Description: "Display the bot version",
-	DescriptionLocalizations: utils.LocalizedString{
-		discord.LocaleEnglishUS: "Display the bot version",
-		discord.LocaleFrench:    "Affiche la version du bot",
-	},
+	Name:                     versionName.Default(),
+	NameLocalizations:        versionName.Localizations(),
+	Description:              versionDescription.Default(),
+	DescriptionLocalizations: versionDescription.Localizations(),
 }
 
 func CreateVersionHandler(b *sdk.Bot) handler.CommandHandler {
 	return func(e *handler.CommandEvent) error {
+		locale := e.Locale()
+		d := b.Diagnostics()
+
+		buildDate := d.BuildDate
+		if buildDate == "" {
+			buildDate = versionUnknown.T(locale)
+		}
+		embed := discord.NewEmbedBuilder().
+			SetTitle(d.Bot).
+			AddField(versionVersion.T(locale), fmt.Sprintf("`%s`", d.Version), true).
+			AddField(versionCommit.T(locale), fmt.Sprintf("`%s`", d.Commit), true).
+			AddField(versionBuildDate.T(locale), buildDate, true).
+			AddField(versionGo.T(locale), d.GoVersion, true).
+			AddField(versionUptime.T(locale), discord.FormattedTimestampMention(d.StartedAt.Unix(), discord.TimestampStyleRelative), true).
+			AddField(versionLatency.T(locale), fmt.Sprintf("%d ms", d.Latency), true).
+			AddField(versionShard.T(locale), fmt.Sprintf("%d/%d", d.ShardID+1, max(d.ShardCount, 1)), true).
+			AddField(versionGuilds.T(locale), fmt.Sprint(d.Guilds), true)
+		if len(d.Commands) > 0 {
+			embed.AddField(versionCommands.T(locale), truncateField("`"+strings.Join(d.Commands, "`, `")+"`"), false)
+		}
+		if len(d.Modules) > 0 {
+			modules := make([]string, len(d.Modules))
+			for i, module := range d.Modules {
+				modules[i] = fmt.Sprintf("%s %s", module.Path, module.Version)
+			}
+			embed.AddField(versionModules.T(locale), truncateField("```\n"+strings.Join(modules, "\n")+"\n```"), false)
+		}
+
 		return e.CreateMessage(discord.MessageCreate{
-			Content: fmt.Sprintf("Version: %s\nCommit: %s", b.Version, b.Commit),
+			Embeds: []discord.Embed{embed.Build()},
 		})
 	}
 }
+
+// truncateField keeps whole lines of the value until the embed field limit is reached,
+// the limit is in characters so that multi-byte characters are never split
+func truncateField(value string) string {
+	runes := []rune(value)
+	if len(runes) <= maxFieldLength {
+		return value
+	}
+	if strings.HasPrefix(value, "```") {
+		kept := string(runes[:maxFieldLength-utf8.RuneCountInString("\n…\n```")])
+		return kept[:strings.LastIndex(kept, "\n")] + "\n…\n```"
+	}
+	return string(runes[:maxFieldLength-1]) + "…"
+}
@@ -0,0 +1,52 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestTruncateField(t *testing.T) {
+	lines := make([]string, 200)
+	for i := range lines {
+		lines[i] = "github.com/disgoorg/module v0.0.1"
+	}
+	tests := []struct {
+		name   string
+		value  string
+		suffix string
+	}{
+		{"short", "`/help`, `/version`", ""},
+		{"ascii", strings.Repeat("a", 2000), "…"},
+		{"multi-byte characters", strings.Repeat("é", 1023) + "✨🚀", "…"},
+		{"code block", "```\n" + strings.Join(lines, "\n") + "\n```", "\n…\n```"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := truncateField(tt.value)
+			if tt.suffix == "" {
+				if got != tt.value {
+					t.Errorf("truncateField changed a value under the limit: %q", got)
+				}
+				return
+			}
+			if !utf8.ValidString(got) {
+				t.Errorf("truncateField split a character: %q", got[len(got)-8:])
+			}
+			if length := utf8.RuneCountInString(got); length > maxFieldLength {
+				t.Errorf("truncated value has %d characters, want at most %d", length, maxFieldLength)
+			}
+			if !strings.HasSuffix(got, tt.suffix) {
+				t.Errorf("truncated value ends with %q, want %q", got[len(got)-10:], tt.suffix)
+			}
+		})
+	}
+
+	// Code blocks keep whole lines
+	got := truncateField("```\n" + strings.Join(lines, "\n") + "\n```")
+	for _, line := range strings.Split(strings.TrimSuffix(strings.TrimPrefix(got, "```\n"), "\n…\n```"), "\n") {
+		if line != lines[0] {
+			t.Errorf("truncated code block has a partial line %q", line)
+		}
+	}
+}
@@ -1,17 +1,30 @@
 package components
 
 import (
-	"github.com/bil0u/galaxy-os/sdk/utils"
+	"time"
+
+	"github.com/bil0u/galaxy-os/sdk/customids"
+	"github.com/bil0u/galaxy-os/sdk/i18n"
 	"github.com/disgoorg/disgo/discord"
 	"github.com/disgoorg/disgo/handler"
 	"github.com/disgoorg/json"
 )
 
-func TestComponent(e *handler.ComponentEvent) error {
+type TestButtonState struct {
+	Choice string
+}
+
+var TestButton = customids.Codec[TestButtonState]{
+	Route:   "/test-button",
+	Signed:  true,
+	TTL:     time.Hour,
+	Handler: TestComponent,
+}
+
+var testUpdatedMessage = i18n.Declare("components.test.responses.updated")
+
+func TestComponent(e *handler.ComponentEvent, state TestButtonState) error {
 	return e.UpdateMessage(discord.MessageUpdate{
-		Content: json.Ptr(utils.LocalizedString{
-			discord.LocaleEnglishUS: "The text has been updated",
-			discord.LocaleFrench:    "Le texte a été mis à jour",
-		}.String(e.Locale())),
+		Content: json.Ptr(testUpdatedMessage.T(e.Locale(), i18n.Vars{"choice": state.Choice})),
 	})
 }
@@ -0,0 +1,45 @@
+package components
+
+import (
+	"strings"
+
+	"github.com/bil0u/galaxy-os/sdk/i18n"
+	"github.com/bil0u/galaxy-os/sdk/middlewares"
+	"github.com/bil0u/galaxy-os/sdk/modals"
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/handler"
+)
+
+type TestFormData struct {
+	Title string `name:"title" required:"true" max:"50"`
+	Text  string `name:"text" style:"paragraph" min:"10" max:"1000"`
+}
+
+var testFormBlankTitle = i18n.Declare("components.test_form.errors.blank_title")
+
+func (d TestFormData) Validate() error {
+	if strings.TrimSpace(d.Title) == "" {
+		return middlewares.NewValidationError(testFormBlankTitle.With(nil))
+	}
+	return nil
+}
+
+var TestForm = modals.Must(modals.Modal[TestFormData]{
+	Key:      "components.test_form",
+	CustomID: "/test-form",
+	Handler:  TestFormHandler,
+})
+
+var testFormSubmittedMessage = i18n.Declare("components.test_form.responses.submitted")
+
+func TestFormHandler(e *handler.ModalEvent, data TestFormData) error {
+	return e.CreateMessage(discord.NewMessageCreateBuilder().
+		SetContent(testFormSubmittedMessage.T(e.Locale(), i18n.Vars{"title": data.Title})).
+		SetEphemeral(true).
+		Build(),
+	)
+}
+
+func TestFormButton(e *handler.ComponentEvent) error {
+	return TestForm.Open(e)
+}
@@ -38,8 +38,11 @@ func LoadConfig(path string, cfg *Config) (*Config, error) {
 }
 
 type Config struct {
-	Log LogConfig `toml:"log"`
-	Bot BotConfig `toml:"bot"`
+	Log    LogConfig    `toml:"log"`
+	Bot    BotConfig    `toml:"bot"`
+	Health HealthConfig `toml:"health"`
+	// Generators are the template file generators run in generator mode, besides the built-in ones
+	Generators []GeneratorConfig `toml:"generators"`
 }
 
 type BotConfig struct {
@@ -48,6 +51,14 @@ type BotConfig struct {
 	DevGuilds     []snowflake.ID                  `toml:"dev_guilds"`
 	Guilds        []snowflake.ID                  `toml:"guilds"`
 	GuildsRoles   map[snowflake.ID][]snowflake.ID `toml:"guilds_roles"`
+	// AdminRoles can use the /admin command
+	AdminRoles []snowflake.ID `toml:"admin_roles"`
+	// ComponentsSecret signs the state carried by components custom IDs
+	ComponentsSecret string `toml:"components_secret"`
+	// GuildAliases name the guilds in the generated enums, guilds without alias use their own name
+	GuildAliases map[snowflake.ID]string `toml:"guild_aliases"`
+	// EnumNames override the identifiers of roles and channels in the generated enums, by ID
+	EnumNames map[snowflake.ID]string `toml:"enum_names"`
 }
 
 // GetGuildRoles returns the roles for a specific guild
@@ -58,6 +69,11 @@ func (c BotConfig) GetGuildRoles(guildID snowflake.ID) []snowflake.ID {
 	return nil
 }
 
+// GetGuildAlias returns the alias of a guild, or an empty string if it has none
+func (c BotConfig) GetGuildAlias(guildID snowflake.ID) string {
+	return c.GuildAliases[guildID]
+}
+
 // GetGuildsToSync returns the guilds to sync
 func (c BotConfig) GetGuildsToSync() []snowflake.ID {
 	if len(c.DevGuilds) > 0 {
@@ -67,7 +83,43 @@ func (c BotConfig) GetGuildsToSync() []snowflake.ID {
 }
 
 type LogConfig struct {
-	Level     slog.Level `toml:"level"`
-	Format    string     `toml:"format"`
-	AddSource bool       `toml:"add_source"`
+	// Level defaults to debug with the json format, and to info with the text format
+	Level     *slog.Level `toml:"level"`
+	Format    string      `toml:"format"`
+	AddSource bool        `toml:"add_source"`
+}
+
+// MinLevel returns the configured level, or the default level of the format
+func (c LogConfig) MinLevel() slog.Level {
+	if c.Level != nil {
+		return *c.Level
+	}
+	if c.Format == "json" {
+		return slog.LevelDebug
+	}
+	return slog.LevelInfo
+}
+
+type HealthConfig struct {
+	// Address of the health endpoint, e.g. ":8080". The endpoint is disabled when empty
+	Address string `toml:"address"`
+}
+
+// GeneratorConfig declares a generator rendering a template file with data read from the guilds,
+// see docs/generators.md for the data given to templates
+type GeneratorConfig struct {
+	// Source of the items given to the template: "roles", "channels", "emojis" or "members"
+	Source string `toml:"source"`
+	// Role only keeps the members having this role, for the "members" source
+	Role snowflake.ID `toml:"role"`
+	// Filter is a template expression, items for which it is empty or false are dropped, e.g. 'eq .Type "voice"'
+	Filter string `toml:"filter"`
+	// Sort is a template expression giving the key to sort items by, e.g. ".Name"
+	Sort string `toml:"sort"`
+	// Descending sorts items from the highest key to the lowest
+	Descending bool `toml:"descending"`
+	// Template is the path of the text/template file to render
+	Template string `toml:"template"`
+	// Output is the path of the generated file
+	Output string `toml:"output"`
 }
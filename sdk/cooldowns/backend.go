@@ -0,0 +1,89 @@
+package cooldowns
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// Backend stores the state of the cooldown buckets
+type Backend interface {
+	// Take consumes a token from the bucket identified by key. If the bucket is empty,
+	// it returns false and the time at which a token will be available
+	Take(key string, limit Limit, now time.Time) (bool, time.Time)
+}
+
+// Limit is a token bucket refilled with one token every Every, holding at most Burst tokens
+type Limit struct {
+	Every time.Duration
+	Burst int
+}
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+	// refill is the time needed to fill the bucket from empty
+	refill time.Duration
+}
+
+// MemoryBackend is a Backend keeping the buckets in memory
+type MemoryBackend struct {
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastPrune time.Time
+}
+
+const pruneInterval = time.Minute
+
+func NewMemoryBackend() *MemoryBackend {
+	return &MemoryBackend{buckets: map[string]*bucket{}}
+}
+
+func (b *MemoryBackend) Take(key string, limit Limit, now time.Time) (bool, time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if now.Sub(b.lastPrune) > pruneInterval {
+		b.prune(now)
+		b.lastPrune = now
+	}
+
+	burst := float64(max(limit.Burst, 1))
+	bk, ok := b.buckets[key]
+	if !ok {
+		bk = &bucket{tokens: burst, last: now, refill: limit.Every * time.Duration(burst)}
+		b.buckets[key] = bk
+	}
+
+	// Refill the bucket for the time elapsed since the last take
+	if limit.Every > 0 {
+		elapsed := now.Sub(bk.last)
+		bk.tokens = math.Min(burst, bk.tokens+float64(elapsed)/float64(limit.Every))
+	} else {
+		bk.tokens = burst
+	}
+	bk.last = now
+
+	if bk.tokens >= 1 {
+		bk.tokens--
+		return true, now
+	}
+	wait := time.Duration((1 - bk.tokens) * float64(limit.Every))
+	return false, now.Add(wait)
+}
+
+// Reset empties the backend
+func (b *MemoryBackend) Reset() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.buckets = map[string]*bucket{}
+}
+
+// prune removes the buckets that have been idle long enough to be full again
+func (b *MemoryBackend) prune(now time.Time) {
+	for key, bk := range b.buckets {
+		if now.Sub(bk.last) > bk.refill {
+			delete(b.buckets, key)
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package cooldowns
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMemoryBackendTake(t *testing.T) {
+	type take struct {
+		// at is the time of the take, relative to the start of the test
+		at      time.Duration
+		key     string
+		allowed bool
+		// retryAt is the expected retry time of denied takes, relative to the start of the test
+		retryAt time.Duration
+	}
+	tests := []struct {
+		name  string
+		limit Limit
+		takes []take
+	}{
+		{
+			name:  "burst is consumed at once",
+			limit: Limit{Every: 10 * time.Second, Burst: 3},
+			takes: []take{
+				{at: 0, allowed: true},
+				{at: 0, allowed: true},
+				{at: 0, allowed: true},
+				{at: 0, allowed: false, retryAt: 10 * time.Second},
+			},
+		},
+		{
+			name:  "rate of one take per period",
+			limit: Limit{Every: 5 * time.Second, Burst: 1},
+			takes: []take{
+				{at: 0, allowed: true},
+				{at: 2 * time.Second, allowed: false, retryAt: 5 * time.Second},
+				{at: 5 * time.Second, allowed: true},
+				{at: 6 * time.Second, allowed: false, retryAt: 10 * time.Second},
+				{at: 10 * time.Second, allowed: true},
+			},
+		},
+		{
+			name:  "tokens are refilled over time",
+			limit: Limit{Every: 10 * time.Second, Burst: 2},
+			takes: []take{
+				{at: 0, allowed: true},
+				{at: 0, allowed: true},
+				{at: 5 * time.Second, allowed: false, retryAt: 10 * time.Second},
+				{at: 10 * time.Second, allowed: true},
+				{at: 10 * time.Second, allowed: false, retryAt: 20 * time.Second},
+			},
+		},
+		{
+			name:  "refill stops at the burst",
+			limit: Limit{Every: 10 * time.Second, Burst: 2},
+			takes: []take{
+				{at: 0, allowed: true},
+				{at: time.Hour, allowed: true},
+				{at: time.Hour, allowed: true},
+				{at: time.Hour, allowed: false, retryAt: time.Hour + 10*time.Second},
+			},
+		},
+		{
+			name:  "burst defaults to one",
+			limit: Limit{Every: 10 * time.Second},
+			takes: []take{
+				{at: 0, allowed: true},
+				{at: 0, allowed: false, retryAt: 10 * time.Second},
+			},
+		},
+		{
+			name:  "no period never throttles",
+			limit: Limit{Burst: 1},
+			takes: []take{
+				{at: 0, allowed: true},
+				{at: 0, allowed: true},
+				{at: 0, allowed: true},
+			},
+		},
+		{
+			name:  "keys have their own bucket",
+			limit: Limit{Every: 10 * time.Second, Burst: 1},
+			takes: []take{
+				{at: 0, key: "a", allowed: true},
+				{at: 0, key: "b", allowed: true},
+				{at: 0, key: "a", allowed: false, retryAt: 10 * time.Second},
+				{at: 0, key: "b", allowed: false, retryAt: 10 * time.Second},
+			},
+		},
+	}
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backend := NewMemoryBackend()
+			for i, take := range tt.takes {
+				allowed, retryAt := backend.Take(take.key, tt.limit, start.Add(take.at))
+				if allowed != take.allowed {
+					t.Fatalf("take %d at %s: allowed = %v, want %v", i, take.at, allowed, take.allowed)
+				}
+				if !allowed && !retryAt.Equal(start.Add(take.retryAt)) {
+					t.Errorf("take %d at %s: retry at %s, want %s", i, take.at, retryAt.Sub(start), take.retryAt)
+				}
+			}
+		})
+	}
+}
+
+func TestMemoryBackendPrune(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	backend := NewMemoryBackend()
+	limit := Limit{Every: 10 * time.Second, Burst: 1}
+
+	backend.Take("idle", limit, start)
+	backend.Take("active", limit, start.Add(2*time.Minute))
+	if _, ok := backend.buckets["idle"]; ok {
+		t.Error("idle bucket was not pruned")
+	}
+	if _, ok := backend.buckets["active"]; !ok {
+		t.Error("active bucket was pruned")
+	}
+}
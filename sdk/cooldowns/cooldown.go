@@ -0,0 +1,112 @@
+package cooldowns
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/bil0u/galaxy-os/sdk/enums"
+	"github.com/bil0u/galaxy-os/sdk/i18n"
+	"github.com/bil0u/galaxy-os/sdk/middlewares"
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/handler"
+)
+
+var retryMessage = i18n.Declare("cooldowns.retry")
+
+// Scope defines who shares a cooldown bucket
+type Scope int
+
+const (
+	// ScopeUser gives each user their own bucket
+	ScopeUser Scope = iota
+	// ScopeChannel shares a bucket between all users of a channel
+	ScopeChannel
+	// ScopeGuild shares a bucket between all users of a guild
+	ScopeGuild
+	// ScopeGlobal shares a single bucket between everyone
+	ScopeGlobal
+)
+
+// DefaultBackend is the backend used by cooldowns that don't set one
+var DefaultBackend Backend = NewMemoryBackend()
+
+// Cooldown throttles the interactions of a route
+type Cooldown struct {
+	// Name identifies the bucket, routes using the same name share their cooldown.
+	// Defaults to the path of the interaction
+	Name  string
+	Limit Limit
+	Scope Scope
+	// ExemptRoles are not throttled
+	ExemptRoles []enums.RoleEnum
+	// Backend defaults to DefaultBackend
+	Backend Backend
+	// Now defaults to time.Now, it can be overridden in tests
+	Now func() time.Time
+}
+
+// Middleware returns a middleware enforcing the cooldown, throttled users get a
+// localized reply telling them when they can try again
+func (c Cooldown) Middleware() handler.Middleware {
+	return func(next handler.Handler) handler.Handler {
+		return func(e *handler.InteractionEvent) error {
+			// Autocomplete interactions are sent on each keystroke, they are never throttled
+			if e.Type() == discord.InteractionTypeAutocomplete || c.isExempt(e) {
+				return next(e)
+			}
+
+			backend := c.Backend
+			if backend == nil {
+				backend = DefaultBackend
+			}
+			now := time.Now()
+			if c.Now != nil {
+				now = c.Now()
+			}
+
+			allowed, retryAt := backend.Take(c.key(e), c.Limit, now)
+			if !allowed {
+				return middlewares.NewRateLimitedError(retryMessage.With(i18n.Vars{
+					"retry": discord.FormattedTimestampMention(retryAt.Add(time.Second).Unix(), discord.TimestampStyleRelative),
+				}))
+			}
+			return next(e)
+		}
+	}
+}
+
+func (c Cooldown) isExempt(e *handler.InteractionEvent) bool {
+	member := e.Member()
+	if member == nil {
+		return false
+	}
+	for _, role := range c.ExemptRoles {
+		if slices.Contains(member.RoleIDs, role.ID()) {
+			return true
+		}
+	}
+	return false
+}
+
+// key returns the bucket key of the interaction, according to the scope
+func (c Cooldown) key(e *handler.InteractionEvent) string {
+	name := c.Name
+	if name == "" {
+		name = middlewares.InteractionPath(e)
+	}
+	switch c.Scope {
+	case ScopeChannel:
+		return fmt.Sprintf("%s:channel:%s", name, e.Channel().ID())
+	case ScopeGuild:
+		if e.GuildID() != nil {
+			return fmt.Sprintf("%s:guild:%s", name, e.GuildID())
+		}
+		// Direct messages have no guild, fall back to the channel
+		return fmt.Sprintf("%s:channel:%s", name, e.Channel().ID())
+	case ScopeGlobal:
+		return fmt.Sprintf("%s:global", name)
+	default:
+		return fmt.Sprintf("%s:user:%s", name, e.User().ID)
+	}
+}
@@ -0,0 +1,107 @@
+package cooldowns
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/events"
+	"github.com/disgoorg/disgo/handler"
+)
+
+// newTestEvent returns a slash command interaction of a user in a channel, in a direct message if guildID is empty
+func newTestEvent(t *testing.T, userID string, channelID string, guildID string) *handler.InteractionEvent {
+	t.Helper()
+	user := fmt.Sprintf(`{"id": %q, "username": "user-%s"}`, userID, userID)
+	var data string
+	if guildID == "" {
+		data = fmt.Sprintf(`{
+			"id": "1", "application_id": "2", "type": 2, "token": "token", "version": 1, "locale": "fr",
+			"channel_id": %[1]q, "channel": {"id": %[1]q, "type": 1, "permissions": "0"},
+			"user": %[2]s,
+			"data": {"id": "3", "name": "test", "type": 1}
+		}`, channelID, user)
+	} else {
+		data = fmt.Sprintf(`{
+			"id": "1", "application_id": "2", "type": 2, "token": "token", "version": 1, "locale": "fr",
+			"guild_id": %[1]q,
+			"channel_id": %[2]q, "channel": {"id": %[2]q, "type": 0, "guild_id": %[1]q, "name": "channel", "permissions": "0"},
+			"member": {"user": %[3]s, "roles": [], "permissions": "0", "joined_at": "2024-01-01T00:00:00Z"},
+			"data": {"id": "3", "name": "test", "type": 1}
+		}`, guildID, channelID, user)
+	}
+	interaction, err := discord.UnmarshalInteraction([]byte(data))
+	if err != nil {
+		t.Fatalf("failed to read interaction: %v", err)
+	}
+	return &handler.InteractionEvent{InteractionCreate: &events.InteractionCreate{Interaction: interaction}}
+}
+
+func TestCooldownScopes(t *testing.T) {
+	type interaction struct{ user, channel, guild string }
+	tests := []struct {
+		name   string
+		scope  Scope
+		first  interaction
+		second interaction
+		// shared is true if the second interaction uses the bucket of the first one
+		shared bool
+	}{
+		{"user: same user", ScopeUser, interaction{"10", "20", "30"}, interaction{"10", "21", "31"}, true},
+		{"user: other user", ScopeUser, interaction{"10", "20", "30"}, interaction{"11", "20", "30"}, false},
+		{"channel: same channel", ScopeChannel, interaction{"10", "20", "30"}, interaction{"11", "20", "30"}, true},
+		{"channel: other channel", ScopeChannel, interaction{"10", "20", "30"}, interaction{"10", "21", "30"}, false},
+		{"guild: same guild", ScopeGuild, interaction{"10", "20", "30"}, interaction{"11", "21", "30"}, true},
+		{"guild: other guild", ScopeGuild, interaction{"10", "20", "30"}, interaction{"10", "20", "31"}, false},
+		{"guild: direct messages use the channel", ScopeGuild, interaction{"10", "20", ""}, interaction{"10", "21", ""}, false},
+		{"global: everyone", ScopeGlobal, interaction{"10", "20", "30"}, interaction{"11", "21", "31"}, true},
+	}
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	next := func(e *handler.InteractionEvent) error { return nil }
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := Cooldown{
+				Name:    "test",
+				Limit:   Limit{Every: time.Minute, Burst: 1},
+				Scope:   tt.scope,
+				Backend: NewMemoryBackend(),
+				Now:     func() time.Time { return now },
+			}.Middleware()(next)
+
+			if err := h(newTestEvent(t, tt.first.user, tt.first.channel, tt.first.guild)); err != nil {
+				t.Fatalf("first interaction was throttled: %v", err)
+			}
+			err := h(newTestEvent(t, tt.second.user, tt.second.channel, tt.second.guild))
+			if shared := err != nil; shared != tt.shared {
+				t.Errorf("second interaction throttled = %v, want %v", shared, tt.shared)
+			}
+		})
+	}
+}
+
+func TestCooldownNames(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	backend := NewMemoryBackend()
+	next := func(e *handler.InteractionEvent) error { return nil }
+	newHandler := func(name string) handler.Handler {
+		return Cooldown{
+			Name:    name,
+			Limit:   Limit{Every: time.Minute, Burst: 1},
+			Backend: backend,
+			Now:     func() time.Time { return now },
+		}.Middleware()(next)
+	}
+
+	e := newTestEvent(t, "10", "20", "30")
+	if err := newHandler("a")(e); err != nil {
+		t.Fatalf("first interaction was throttled: %v", err)
+	}
+	if err := newHandler("b")(e); err != nil {
+		t.Errorf("cooldown 'b' shares the bucket of cooldown 'a': %v", err)
+	}
+	if err := newHandler("a")(e); err == nil {
+		t.Error("cooldown 'a' was not throttled")
+	}
+}
@@ -0,0 +1,311 @@
+package customids
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bil0u/galaxy-os/sdk/i18n"
+	"github.com/bil0u/galaxy-os/sdk/middlewares"
+	"github.com/disgoorg/disgo/handler"
+)
+
+// A custom ID is made of the route of the codec and a payload, e.g. "/poll/1.k3x.a~d.s8fj3k.<signature>":
+//
+//	version   format of the payload, bumped on breaking changes
+//	fields    exported fields of the state, in order, separated by dots
+//	expiry    unix time in base 36, when the codec has a TTL
+//	signature truncated HMAC-SHA256 of the custom ID, when the codec is signed
+//
+// Integers are written in base 36, strings are escaped so that they never contain '/' or '.'
+const (
+	payloadVersion = "1"
+	separator      = "."
+	// MaxLength is the maximum length of a custom ID allowed by discord
+	MaxLength = 100
+	// signatureSize is the number of bytes of the HMAC kept in the custom ID
+	signatureSize = 12
+	stateVar      = "state"
+)
+
+var (
+	invalidMessage = i18n.Declare("customids.invalid")
+	expiredMessage = i18n.Declare("customids.expired")
+)
+
+var (
+	// ErrInvalid is returned when a custom ID is malformed or its signature does not match
+	ErrInvalid = errors.New("invalid custom ID")
+	// ErrExpired is returned when a custom ID is past its expiry
+	ErrExpired = errors.New("expired custom ID")
+)
+
+// secret is the key used to sign custom IDs, a random one is generated until SetSecret is called
+var secret = randomSecret()
+
+func randomSecret() []byte {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		panic(fmt.Sprintf("failed to generate custom IDs secret: %v", err))
+	}
+	return key
+}
+
+// SetSecret sets the key used to sign custom IDs. When empty, a random key is kept,
+// signed custom IDs then become invalid when the bot restarts
+func SetSecret(key string) {
+	if key == "" {
+		slog.Warn("No components secret configured, signed components will not survive a restart")
+		return
+	}
+	secret = []byte(key)
+}
+
+// Codec encodes a typed state into the custom IDs of a component route, and decodes it back
+// when the component is used. Supported field types are strings, booleans and integers,
+// including snowflake IDs
+type Codec[T any] struct {
+	// Route is the path prefix of the custom IDs, e.g. "/poll/vote"
+	Route string
+	// Signed custom IDs cannot be forged by users
+	Signed bool
+	// TTL makes custom IDs expire, zero means they never do
+	TTL     time.Duration
+	Handler func(e *handler.ComponentEvent, state T) error
+	// Now defaults to time.Now, it can be overridden in tests
+	Now func() time.Time
+}
+
+func (c Codec[T]) now() time.Time {
+	if c.Now != nil {
+		return c.Now()
+	}
+	return time.Now()
+}
+
+// Encode returns the custom ID carrying the given state
+func (c Codec[T]) Encode(state T) (string, error) {
+	v := reflect.ValueOf(state)
+	if v.Kind() != reflect.Struct {
+		return "", fmt.Errorf("state of '%s' must be a struct, got %s", c.Route, v.Kind())
+	}
+
+	parts := []string{payloadVersion}
+	for i := 0; i < v.NumField(); i++ {
+		if !v.Type().Field(i).IsExported() {
+			continue
+		}
+		part, err := encodeField(v.Field(i))
+		if err != nil {
+			return "", fmt.Errorf("state of '%s' field '%s': %w", c.Route, v.Type().Field(i).Name, err)
+		}
+		parts = append(parts, part)
+	}
+	if c.TTL > 0 {
+		parts = append(parts, strconv.FormatInt(c.now().Add(c.TTL).Unix(), 36))
+	}
+
+	customID := c.Route + "/" + strings.Join(parts, separator)
+	if c.Signed {
+		customID += separator + sign(customID)
+	}
+	if len(customID) > MaxLength {
+		return "", fmt.Errorf("custom ID of '%s' is %d characters long, discord allows %d", c.Route, len(customID), MaxLength)
+	}
+	return customID, nil
+}
+
+// MustEncode is like Encode but panics if the state cannot be encoded
+func (c Codec[T]) MustEncode(state T) string {
+	customID, err := c.Encode(state)
+	if err != nil {
+		panic(err)
+	}
+	return customID
+}
+
+// Decode returns the state carried by the custom ID, it fails with ErrInvalid or ErrExpired
+// when the custom ID was forged or is too old
+func (c Codec[T]) Decode(customID string) (T, error) {
+	var state T
+	payload, ok := strings.CutPrefix(customID, c.Route+"/")
+	if !ok {
+		return state, fmt.Errorf("%w: route does not match '%s'", ErrInvalid, c.Route)
+	}
+	parts := strings.Split(payload, separator)
+
+	if c.Signed {
+		last := len(parts) - 1
+		signed := strings.TrimSuffix(customID, separator+parts[last])
+		if last < 1 || !hmac.Equal([]byte(parts[last]), []byte(sign(signed))) {
+			return state, fmt.Errorf("%w: bad signature", ErrInvalid)
+		}
+		parts = parts[:last]
+	}
+	if parts[0] != payloadVersion {
+		return state, fmt.Errorf("%w: unknown version '%s'", ErrInvalid, parts[0])
+	}
+	parts = parts[1:]
+
+	if c.TTL > 0 {
+		if len(parts) == 0 {
+			return state, fmt.Errorf("%w: missing expiry", ErrInvalid)
+		}
+		expiry, err := strconv.ParseInt(parts[len(parts)-1], 36, 64)
+		if err != nil {
+			return state, fmt.Errorf("%w: bad expiry", ErrInvalid)
+		}
+		if c.now().Unix() > expiry {
+			return state, ErrExpired
+		}
+		parts = parts[:len(parts)-1]
+	}
+
+	v := reflect.ValueOf(&state).Elem()
+	if v.Kind() != reflect.Struct {
+		return state, fmt.Errorf("state of '%s' must be a struct, got %s", c.Route, v.Kind())
+	}
+	field := 0
+	for i := 0; i < v.NumField(); i++ {
+		if !v.Type().Field(i).IsExported() {
+			continue
+		}
+		if field >= len(parts) {
+			return state, fmt.Errorf("%w: missing fields", ErrInvalid)
+		}
+		if err := decodeField(parts[field], v.Field(i)); err != nil {
+			return state, fmt.Errorf("%w: field '%s': %v", ErrInvalid, v.Type().Field(i).Name, err)
+		}
+		field++
+	}
+	if field != len(parts) {
+		return state, fmt.Errorf("%w: unexpected fields", ErrInvalid)
+	}
+	return state, nil
+}
+
+// Handle decodes the state of the component and calls the codec handler.
+// Forged custom IDs are logged and rejected, expired ones are answered with a localized message
+func (c Codec[T]) Handle(e *handler.ComponentEvent) error {
+	state, err := c.Decode(e.Data.CustomID())
+	if errors.Is(err, ErrExpired) {
+		return middlewares.NewValidationError(expiredMessage.With(nil))
+	}
+	if err != nil {
+		slog.Warn("Rejected component custom ID",
+			slog.String("audit", "invalid_custom_id"),
+			slog.String("user", e.User().ID.String()),
+			slog.String("custom_id", e.Data.CustomID()),
+			slog.Any("err", err),
+		)
+		return middlewares.NewForbiddenError(invalidMessage.With(nil))
+	}
+	return c.Handler(e, state)
+}
+
+// Register adds the route of the codec to the router
+func (c Codec[T]) Register(r handler.Router) {
+	r.Component(c.Route+"/{"+stateVar+"}", c.Handle)
+}
+
+func sign(customID string) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(customID))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil)[:signatureSize])
+}
+
+func encodeField(v reflect.Value) (string, error) {
+	switch v.Kind() {
+	case reflect.String:
+		return escape(v.String()), nil
+	case reflect.Bool:
+		if v.Bool() {
+			return "1", nil
+		}
+		return "0", nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 36), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 36), nil
+	default:
+		return "", fmt.Errorf("unsupported type %s", v.Type())
+	}
+}
+
+func decodeField(part string, v reflect.Value) error {
+	switch v.Kind() {
+	case reflect.String:
+		s, err := unescape(part)
+		if err != nil {
+			return err
+		}
+		v.SetString(s)
+	case reflect.Bool:
+		switch part {
+		case "1":
+			v.SetBool(true)
+		case "0":
+			v.SetBool(false)
+		default:
+			return fmt.Errorf("invalid boolean '%s'", part)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(part, 36, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(part, 36, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+	default:
+		return fmt.Errorf("unsupported type %s", v.Type())
+	}
+	return nil
+}
+
+// Escaped characters of strings, the escape character itself comes first
+var escapes = [][2]string{{"~", "~~"}, {".", "~d"}, {"/", "~s"}}
+
+func escape(s string) string {
+	for _, e := range escapes {
+		s = strings.ReplaceAll(s, e[0], e[1])
+	}
+	return s
+}
+
+func unescape(s string) (string, error) {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '~' {
+			b.WriteByte(s[i])
+			continue
+		}
+		if i+1 >= len(s) {
+			return "", fmt.Errorf("dangling escape in '%s'", s)
+		}
+		i++
+		switch s[i] {
+		case '~':
+			b.WriteByte('~')
+		case 'd':
+			b.WriteByte('.')
+		case 's':
+			b.WriteByte('/')
+		default:
+			return "", fmt.Errorf("unknown escape '~%c' in '%s'", s[i], s)
+		}
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,153 @@
+package customids
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/disgoorg/snowflake/v2"
+)
+
+type testState struct {
+	Name    string
+	Enabled bool
+	Count   int
+	Page    uint8
+	UserID  snowflake.ID
+	private string
+}
+
+func TestCodecRoundTrip(t *testing.T) {
+	states := []testState{
+		{},
+		{Name: "simple", Enabled: true, Count: 42, Page: 3, UserID: 1284597292459888824},
+		{Name: "a.b/c~d", Count: -7},
+		{Name: "~d~s~~", Enabled: true},
+		{Name: "équipage ✨"},
+	}
+	codecs := map[string]Codec[testState]{
+		"plain":  {Route: "/test"},
+		"signed": {Route: "/test", Signed: true},
+		"ttl":    {Route: "/test", Signed: true, TTL: time.Hour},
+	}
+	for name, codec := range codecs {
+		for _, state := range states {
+			customID, err := codec.Encode(state)
+			if err != nil {
+				t.Fatalf("%s: Encode(%+v) failed: %v", name, state, err)
+			}
+			if !strings.HasPrefix(customID, "/test/") {
+				t.Errorf("%s: custom ID '%s' does not start with the route", name, customID)
+			}
+			decoded, err := codec.Decode(customID)
+			if err != nil {
+				t.Fatalf("%s: Decode('%s') failed: %v", name, customID, err)
+			}
+			if decoded != state {
+				t.Errorf("%s: Decode('%s') = %+v, want %+v", name, customID, decoded, state)
+			}
+		}
+	}
+}
+
+func TestCodecTampering(t *testing.T) {
+	codec := Codec[testState]{Route: "/test", Signed: true, TTL: time.Hour}
+	customID := codec.MustEncode(testState{Name: "admin", Count: 1})
+	payload := strings.TrimPrefix(customID, "/test/")
+	parts := strings.Split(payload, separator)
+
+	tests := []struct {
+		name     string
+		customID string
+	}{
+		{"changed field", "/test/" + strings.Replace(payload, "admin", "owner", 1)},
+		{"changed expiry", "/test/" + strings.Join(append(parts[:len(parts)-2:len(parts)-2], "zzzzzz", parts[len(parts)-1]), separator)},
+		{"changed signature", customID[:len(customID)-1] + string(customID[len(customID)-1]^1)},
+		{"removed signature", strings.TrimSuffix(customID, separator+parts[len(parts)-1])},
+		{"other route", "/other/" + payload},
+		{"other secret", Codec[testState]{Route: "/test", TTL: time.Hour}.MustEncode(testState{Name: "admin"}) + ".AAAAAAAAAAAAAAAA"},
+		{"empty payload", "/test/"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := codec.Decode(tt.customID); !errors.Is(err, ErrInvalid) {
+				t.Errorf("Decode('%s') = %v, want ErrInvalid", tt.customID, err)
+			}
+		})
+	}
+}
+
+func TestCodecMalformed(t *testing.T) {
+	codec := Codec[testState]{Route: "/test"}
+	tests := []struct {
+		name     string
+		customID string
+	}{
+		{"unknown version", "/test/2.a.0.0.0.0"},
+		{"missing fields", "/test/1.a.0"},
+		{"unexpected fields", "/test/1.a.0.0.0.0.0"},
+		{"bad boolean", "/test/1.a.2.0.0.0"},
+		{"bad integer", "/test/1.a.0.!.0.0"},
+		{"overflowing integer", "/test/1.a.0.0.zz.0"},
+		{"bad escape", "/test/1.~x.0.0.0.0"},
+		{"dangling escape", "/test/1.a~.0.0.0.0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := codec.Decode(tt.customID); !errors.Is(err, ErrInvalid) {
+				t.Errorf("Decode('%s') = %v, want ErrInvalid", tt.customID, err)
+			}
+		})
+	}
+}
+
+func TestCodecExpiry(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	codec := Codec[testState]{Route: "/test", Signed: true, TTL: time.Hour, Now: func() time.Time { return now }}
+	customID := codec.MustEncode(testState{Name: "state"})
+
+	tests := []struct {
+		name    string
+		elapsed time.Duration
+		want    error
+	}{
+		{"fresh", 0, nil},
+		{"before expiry", 59 * time.Minute, nil},
+		{"at expiry", time.Hour, nil},
+		{"after expiry", time.Hour + time.Second, ErrExpired},
+		{"long after expiry", 24 * time.Hour, ErrExpired},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			later := codec
+			later.Now = func() time.Time { return now.Add(tt.elapsed) }
+			if _, err := later.Decode(customID); !errors.Is(err, tt.want) {
+				t.Errorf("Decode after %s = %v, want %v", tt.elapsed, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestCodecOversize(t *testing.T) {
+	codec := Codec[testState]{Route: "/test", Signed: true, TTL: time.Hour}
+	if _, err := codec.Encode(testState{Name: strings.Repeat("a", MaxLength)}); err == nil {
+		t.Error("Encode accepted a custom ID longer than the discord limit")
+	}
+	// Escaping doubles the length of dots
+	if _, err := codec.Encode(testState{Name: strings.Repeat(".", 40)}); err == nil {
+		t.Error("Encode accepted a custom ID longer than the discord limit once escaped")
+	}
+	if _, err := codec.Encode(testState{Name: strings.Repeat("a", 40)}); err != nil {
+		t.Errorf("Encode rejected a custom ID under the discord limit: %v", err)
+	}
+}
+
+func TestCodecUnsupportedState(t *testing.T) {
+	if _, err := (Codec[int]{Route: "/test"}).Encode(1); err == nil {
+		t.Error("Encode accepted a state that is not a struct")
+	}
+	if _, err := (Codec[struct{ Ratio float64 }]{Route: "/test"}).Encode(struct{ Ratio float64 }{1.5}); err == nil {
+		t.Error("Encode accepted an unsupported field type")
+	}
+}
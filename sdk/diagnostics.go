@@ -0,0 +1,135 @@
+package sdk
+
+import (
+	"fmt"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/gateway"
+)
+
+// Module is a Go module the binary was built with
+type Module struct {
+	Path    string `json:"path"`
+	Version string `json:"version"`
+}
+
+// BuildInfo describes the build of the running binary
+type BuildInfo struct {
+	Version   string   `json:"version"`
+	Commit    string   `json:"commit"`
+	BuildDate string   `json:"build_date,omitempty"`
+	GoVersion string   `json:"go_version"`
+	Modules   []Module `json:"modules,omitempty"`
+}
+
+// devVersion is the version of binaries built without ldflags
+const devVersion = "dev"
+
+// ReadBuildInfo returns the build information set by ldflags, completed with the one
+// embedded by the Go toolchain when the flags are absent
+func ReadBuildInfo(version string, commit string, buildDate string) BuildInfo {
+	info := BuildInfo{
+		Version:   version,
+		Commit:    commit,
+		BuildDate: buildDate,
+		GoVersion: runtime.Version(),
+	}
+
+	build, ok := debug.ReadBuildInfo()
+	if !ok {
+		return info
+	}
+	if (info.Version == "" || info.Version == devVersion) && build.Main.Version != "" && build.Main.Version != "(devel)" {
+		info.Version = build.Main.Version
+	}
+	for _, setting := range build.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			if info.Commit == "" || info.Commit == "unknown" {
+				info.Commit = setting.Value[:min(len(setting.Value), 7)]
+			}
+		case "vcs.time":
+			if info.BuildDate == "" {
+				info.BuildDate = setting.Value
+			}
+		}
+	}
+	for _, dep := range build.Deps {
+		if dep.Replace != nil {
+			dep = dep.Replace
+		}
+		info.Modules = append(info.Modules, Module{Path: dep.Path, Version: dep.Version})
+	}
+	return info
+}
+
+func (i BuildInfo) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "version: %s\ncommit: %s\n", i.Version, i.Commit)
+	if i.BuildDate != "" {
+		fmt.Fprintf(&b, "built: %s\n", i.BuildDate)
+	}
+	fmt.Fprintf(&b, "go: %s\n", i.GoVersion)
+	if len(i.Modules) > 0 {
+		b.WriteString("modules:\n")
+		for _, module := range i.Modules {
+			fmt.Fprintf(&b, "  %s %s\n", module.Path, module.Version)
+		}
+	}
+	return b.String()
+}
+
+// Diagnostics is the runtime state of a bot
+type Diagnostics struct {
+	BuildInfo
+	Bot       string    `json:"bot"`
+	Status    string    `json:"status"`
+	StartedAt time.Time `json:"started_at"`
+	Uptime    string    `json:"uptime"`
+	// Latency is the gateway heartbeat latency, in milliseconds
+	Latency    int64 `json:"latency_ms"`
+	ShardID    int   `json:"shard_id"`
+	ShardCount int   `json:"shard_count"`
+	Guilds     int   `json:"guilds"`
+	// Commands are the names of the commands of the bot, slash commands are prefixed with /
+	Commands []string `json:"commands"`
+}
+
+// Ready returns true if the gateway is connected and ready to receive events
+func (d Diagnostics) Ready() bool {
+	return d.Status == gateway.StatusReady.String()
+}
+
+// Diagnostics returns the build information and runtime state of the bot
+func (b *Bot) Diagnostics() Diagnostics {
+	d := Diagnostics{
+		BuildInfo: b.Build,
+		Bot:       b.Name,
+		StartedAt: b.StartedAt,
+		Uptime:    time.Since(b.StartedAt).Round(time.Second).String(),
+		Status:    "not connected",
+	}
+	for _, command := range b.parts.Commands {
+		name := command.CommandName()
+		if command.Type() == discord.ApplicationCommandTypeSlash {
+			name = "/" + name
+		}
+		d.Commands = append(d.Commands, name)
+	}
+	if b.Client == nil {
+		return d
+	}
+	if b.Client.HasGateway() {
+		gw := b.Client.Gateway()
+		d.Status = gw.Status().String()
+		d.Latency = gw.Latency().Milliseconds()
+		d.ShardID = gw.ShardID()
+		d.ShardCount = gw.ShardCount()
+	}
+	d.Guilds = b.Client.Caches().GuildsLen()
+	return d
+}
@@ -0,0 +1,20 @@
+package sdk
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+func TestDiagnosticsCommands(t *testing.T) {
+	b := &Bot{parts: BotParts{Commands: []discord.ApplicationCommandCreate{
+		discord.SlashCommandCreate{Name: "version"},
+		discord.UserCommandCreate{Name: "Show member info"},
+		discord.MessageCommandCreate{Name: "Report message"},
+	}}}
+	want := []string{"/version", "Show member info", "Report message"}
+	if got := b.Diagnostics().Commands; !slices.Equal(got, want) {
+		t.Errorf("Diagnostics().Commands = %q, want %q", got, want)
+	}
+}
@@ -4,140 +4,433 @@
 package enums
 
 import (
+	"fmt"
+	"slices"
+
+	"github.com/disgoorg/disgo/discord"
 	"github.com/disgoorg/snowflake/v2"
 )
 
-type GuildCategoryChannelEnum string
-type GuildChannelEnum string
+// GuildCategoryChannelEnum is a category of one of the guilds, identified by its ID
+type GuildCategoryChannelEnum snowflake.ID
 
-const (
-	GuildCategoryChannelQuartiersCommuns        GuildCategoryChannelEnum = "🍪 Quartiers Communs"
-	GuildCategoryChannelQuartiersStrategiques   GuildCategoryChannelEnum = "🚀 Quartiers Stratégiques"
-	GuildCategoryChannelQuartiersProfessionnels GuildCategoryChannelEnum = "💼 Quartiers Professionnels"
-	GuildCategoryChannelQuartiersPrives         GuildCategoryChannelEnum = "🔐 Quartiers Privés"
-	GuildCategoryChannelQuartiersSecurises      GuildCategoryChannelEnum = "🚨 Quartiers Sécurisés"
-)
+// GuildChannelEnum is a channel of one of the guilds, identified by its ID
+type GuildChannelEnum snowflake.ID
 
-const (
-	GuildChannelSasDeDecompression     GuildChannelEnum = "sas-de-décompression"
-	GuildChannelReglesDuVaisseau       GuildChannelEnum = "règles-du-vaisseau"
-	GuildChannelJournalDeBord          GuildChannelEnum = "journal-de-bord"
-	GuildChannelCafeteria              GuildChannelEnum = "cafétéria"
-	GuildChannelBureauDesPlaintes      GuildChannelEnum = "bureau-des-plaintes"
-	GuildChannelHubDeDonnees           GuildChannelEnum = "hub-de-données"
-	GuildChannelIntercom               GuildChannelEnum = "Intercom"
-	GuildChannelDortoirs               GuildChannelEnum = "Dortoirs"
-	GuildChannelPhotocopieuse          GuildChannelEnum = "photocopieuse"
-	GuildChannelPantheon               GuildChannelEnum = "panthéon"
-	GuildChannelPosteDePilotage        GuildChannelEnum = "Poste de pilotage"
-	GuildChannelCentreDeNavigation     GuildChannelEnum = "Centre de navigation"
-	GuildChannelSalleDeCommandement    GuildChannelEnum = "Salle de commandement"
-	GuildChannelBattleground           GuildChannelEnum = "battleground"
-	GuildChannelSalleDeReunion         GuildChannelEnum = "Salle de réunion"
-	GuildChannelLesSmokeursPro         GuildChannelEnum = "les-smokeurs-pro"
-	GuildChannelMurDesOuinsOuins       GuildChannelEnum = "mur-des-ouins-ouins"
-	GuildChannelRegistreDeSurveillance GuildChannelEnum = "registre-de-surveillance"
-	GuildChannelLaboratoire            GuildChannelEnum = "laboratoire"
-	GuildChannelBureauDadministration  GuildChannelEnum = "Bureau d’administration"
-	GuildChannelCapsuleDejection       GuildChannelEnum = "Capsule d’éjection"
-)
+// GalaxyCategories are the categories of the guild 'Galaxy'
+var GalaxyCategories = struct {
+	QuartiersCommuns        GuildCategoryChannelEnum
+	QuartiersStrategiques   GuildCategoryChannelEnum
+	QuartiersProfessionnels GuildCategoryChannelEnum
+	QuartiersPrives         GuildCategoryChannelEnum
+	QuartiersSecurises      GuildCategoryChannelEnum
+}{
+	QuartiersCommuns:        550451099166048297,
+	QuartiersStrategiques:   927666503376076820,
+	QuartiersProfessionnels: 802921893731762237,
+	QuartiersPrives:         1242498003294748775,
+	QuartiersSecurises:      1284621862193729587,
+}
+
+// GalaxyChannels are the channels of the guild 'Galaxy'
+var GalaxyChannels = struct {
+	SasDeDecompression     GuildChannelEnum
+	ReglesDuVaisseau       GuildChannelEnum
+	JournalDeBord          GuildChannelEnum
+	Cafeteria              GuildChannelEnum
+	BureauDesPlaintes      GuildChannelEnum
+	HubDeDonnees           GuildChannelEnum
+	Intercom               GuildChannelEnum
+	Dortoirs               GuildChannelEnum
+	Photocopieuse          GuildChannelEnum
+	Pantheon               GuildChannelEnum
+	PosteDePilotage        GuildChannelEnum
+	CentreDeNavigation     GuildChannelEnum
+	SalleDeCommandement    GuildChannelEnum
+	Battleground           GuildChannelEnum
+	SalleDeReunion         GuildChannelEnum
+	LesSmokeursPro         GuildChannelEnum
+	MurDesOuinsOuins       GuildChannelEnum
+	RegistreDeSurveillance GuildChannelEnum
+	Laboratoire            GuildChannelEnum
+	BureauDadministration  GuildChannelEnum
+	CapsuleDejection       GuildChannelEnum
+}{
+	SasDeDecompression:     1285014271062970470,
+	ReglesDuVaisseau:       1284614516679905311,
+	JournalDeBord:          1285026984606433362,
+	Cafeteria:              550451099166048298,
+	BureauDesPlaintes:      1285332193299796028,
+	HubDeDonnees:           1285024907868241961,
+	Intercom:               934484018207006750,
+	Dortoirs:               1284604571662159872,
+	Photocopieuse:          1198271989148815484,
+	Pantheon:               1285022953481961473,
+	PosteDePilotage:        1148629417451597885,
+	CentreDeNavigation:     1148629459503677601,
+	SalleDeCommandement:    927666617620500541,
+	Battleground:           1280499340577607812,
+	SalleDeReunion:         802922022798753793,
+	LesSmokeursPro:         1265322648074719264,
+	MurDesOuinsOuins:       1286418129714548939,
+	RegistreDeSurveillance: 1284998508897767555,
+	Laboratoire:            1286850367488790581,
+	BureauDadministration:  1285346159346319472,
+	CapsuleDejection:       1284622169350864956,
+}
+
+// GuildCategoryTree is a category with its channels, in server order. Channels without category are in a tree
+// whose category is 0
+type GuildCategoryTree struct {
+	Category GuildCategoryChannelEnum
+	Channels []GuildChannelEnum
+}
+
+// guildChannelTrees are the categories of every guild with their channels, in server order
+var guildChannelTrees = map[snowflake.ID][]GuildCategoryTree{
+	550451098658275358: {
+		{
+			Channels: []GuildChannelEnum{
+				1285014271062970470,
+				1284614516679905311,
+				1285026984606433362,
+			},
+		},
+		{
+			Category: 550451099166048297,
+			Channels: []GuildChannelEnum{
+				550451099166048298,
+				1285332193299796028,
+				1285024907868241961,
+				934484018207006750,
+				1284604571662159872,
+			},
+		},
+		{
+			Category: 927666503376076820,
+			Channels: []GuildChannelEnum{
+				1198271989148815484,
+				1285022953481961473,
+				1148629417451597885,
+				1148629459503677601,
+				927666617620500541,
+			},
+		},
+		{
+			Category: 802921893731762237,
+			Channels: []GuildChannelEnum{
+				1280499340577607812,
+				802922022798753793,
+			},
+		},
+		{
+			Category: 1242498003294748775,
+			Channels: []GuildChannelEnum{
+				1265322648074719264,
+				1286418129714548939,
+			},
+		},
+		{
+			Category: 1284621862193729587,
+			Channels: []GuildChannelEnum{
+				1284998508897767555,
+				1286850367488790581,
+				1285346159346319472,
+				1284622169350864956,
+			},
+		},
+	},
+}
+
+// channelGuilds are the guilds of the categories and channels, in the order of the config
+var channelGuilds = []snowflake.ID{
+	550451098658275358,
+}
+
+// channelExpressions maps the Go expressions of the categories and channels, e.g. GalaxyChannels.Laboratoire, to their ID
+var channelExpressions = map[string]snowflake.ID{
+	"GalaxyCategories.QuartiersCommuns":        550451099166048297,
+	"GalaxyCategories.QuartiersStrategiques":   927666503376076820,
+	"GalaxyCategories.QuartiersProfessionnels": 802921893731762237,
+	"GalaxyCategories.QuartiersPrives":         1242498003294748775,
+	"GalaxyCategories.QuartiersSecurises":      1284621862193729587,
+	"GalaxyChannels.SasDeDecompression":        1285014271062970470,
+	"GalaxyChannels.ReglesDuVaisseau":          1284614516679905311,
+	"GalaxyChannels.JournalDeBord":             1285026984606433362,
+	"GalaxyChannels.Cafeteria":                 550451099166048298,
+	"GalaxyChannels.BureauDesPlaintes":         1285332193299796028,
+	"GalaxyChannels.HubDeDonnees":              1285024907868241961,
+	"GalaxyChannels.Intercom":                  934484018207006750,
+	"GalaxyChannels.Dortoirs":                  1284604571662159872,
+	"GalaxyChannels.Photocopieuse":             1198271989148815484,
+	"GalaxyChannels.Pantheon":                  1285022953481961473,
+	"GalaxyChannels.PosteDePilotage":           1148629417451597885,
+	"GalaxyChannels.CentreDeNavigation":        1148629459503677601,
+	"GalaxyChannels.SalleDeCommandement":       927666617620500541,
+	"GalaxyChannels.Battleground":              1280499340577607812,
+	"GalaxyChannels.SalleDeReunion":            802922022798753793,
+	"GalaxyChannels.LesSmokeursPro":            1265322648074719264,
+	"GalaxyChannels.MurDesOuinsOuins":          1286418129714548939,
+	"GalaxyChannels.RegistreDeSurveillance":    1284998508897767555,
+	"GalaxyChannels.Laboratoire":               1286850367488790581,
+	"GalaxyChannels.BureauDadministration":     1285346159346319472,
+	"GalaxyChannels.CapsuleDejection":          1284622169350864956,
+}
+
+// GuildCategoryChannelNames maps the categories of every guild to their name
+var GuildCategoryChannelNames = map[GuildCategoryChannelEnum]string{
+	550451099166048297:  "🍪 Quartiers Communs",
+	927666503376076820:  "🚀 Quartiers Stratégiques",
+	802921893731762237:  "💼 Quartiers Professionnels",
+	1242498003294748775: "🔐 Quartiers Privés",
+	1284621862193729587: "🚨 Quartiers Sécurisés",
+}
+
+// GuildChannelNames maps the channels of every guild to their name
+var GuildChannelNames = map[GuildChannelEnum]string{
+	1285014271062970470: "sas-de-décompression",
+	1284614516679905311: "règles-du-vaisseau",
+	1285026984606433362: "journal-de-bord",
+	550451099166048298:  "cafétéria",
+	1285332193299796028: "bureau-des-plaintes",
+	1285024907868241961: "hub-de-données",
+	934484018207006750:  "Intercom",
+	1284604571662159872: "Dortoirs",
+	1198271989148815484: "photocopieuse",
+	1285022953481961473: "panthéon",
+	1148629417451597885: "Poste de pilotage",
+	1148629459503677601: "Centre de navigation",
+	927666617620500541:  "Salle de commandement",
+	1280499340577607812: "battleground",
+	802922022798753793:  "Salle de réunion",
+	1265322648074719264: "les-smokeurs-pro",
+	1286418129714548939: "mur-des-ouins-ouins",
+	1284998508897767555: "registre-de-surveillance",
+	1286850367488790581: "laboratoire",
+	1285346159346319472: "Bureau d’administration",
+	1284622169350864956: "Capsule d’éjection",
+}
 
-var GuildCategoryChannelMap = map[GuildCategoryChannelEnum]snowflake.ID{
-	GuildCategoryChannelQuartiersCommuns:        550451099166048297,
-	GuildCategoryChannelQuartiersStrategiques:   927666503376076820,
-	GuildCategoryChannelQuartiersProfessionnels: 802921893731762237,
-	GuildCategoryChannelQuartiersPrives:         1242498003294748775,
-	GuildCategoryChannelQuartiersSecurises:      1284621862193729587,
-}
-
-var GuildChannelMap = map[GuildChannelEnum]snowflake.ID{
-	GuildChannelSasDeDecompression:     1285014271062970470,
-	GuildChannelReglesDuVaisseau:       1284614516679905311,
-	GuildChannelJournalDeBord:          1285026984606433362,
-	GuildChannelCafeteria:              550451099166048298,
-	GuildChannelBureauDesPlaintes:      1285332193299796028,
-	GuildChannelHubDeDonnees:           1285024907868241961,
-	GuildChannelIntercom:               934484018207006750,
-	GuildChannelDortoirs:               1284604571662159872,
-	GuildChannelPhotocopieuse:          1198271989148815484,
-	GuildChannelPantheon:               1285022953481961473,
-	GuildChannelPosteDePilotage:        1148629417451597885,
-	GuildChannelCentreDeNavigation:     1148629459503677601,
-	GuildChannelSalleDeCommandement:    927666617620500541,
-	GuildChannelBattleground:           1280499340577607812,
-	GuildChannelSalleDeReunion:         802922022798753793,
-	GuildChannelLesSmokeursPro:         1265322648074719264,
-	GuildChannelMurDesOuinsOuins:       1286418129714548939,
-	GuildChannelRegistreDeSurveillance: 1284998508897767555,
-	GuildChannelLaboratoire:            1286850367488790581,
-	GuildChannelBureauDadministration:  1285346159346319472,
-	GuildChannelCapsuleDejection:       1284622169350864956,
-}
-
-var GuildChannelCategoryMap = map[GuildChannelEnum]snowflake.ID{
-	GuildChannelSasDeDecompression:     0,
-	GuildChannelReglesDuVaisseau:       0,
-	GuildChannelJournalDeBord:          0,
-	GuildChannelCafeteria:              550451099166048297,
-	GuildChannelBureauDesPlaintes:      550451099166048297,
-	GuildChannelHubDeDonnees:           550451099166048297,
-	GuildChannelIntercom:               550451099166048297,
-	GuildChannelDortoirs:               550451099166048297,
-	GuildChannelPhotocopieuse:          927666503376076820,
-	GuildChannelPantheon:               927666503376076820,
-	GuildChannelPosteDePilotage:        927666503376076820,
-	GuildChannelCentreDeNavigation:     927666503376076820,
-	GuildChannelSalleDeCommandement:    927666503376076820,
-	GuildChannelBattleground:           802921893731762237,
-	GuildChannelSalleDeReunion:         802921893731762237,
-	GuildChannelLesSmokeursPro:         1242498003294748775,
-	GuildChannelMurDesOuinsOuins:       1242498003294748775,
-	GuildChannelRegistreDeSurveillance: 1284621862193729587,
-	GuildChannelLaboratoire:            1284621862193729587,
-	GuildChannelBureauDadministration:  1284621862193729587,
-	GuildChannelCapsuleDejection:       1284621862193729587,
+// GuildChannelGuilds maps the categories and channels of every guild to their guild ID
+var GuildChannelGuilds = map[snowflake.ID]snowflake.ID{
+	550451099166048297:  550451098658275358,
+	927666503376076820:  550451098658275358,
+	802921893731762237:  550451098658275358,
+	1242498003294748775: 550451098658275358,
+	1284621862193729587: 550451098658275358,
+	1285014271062970470: 550451098658275358,
+	1284614516679905311: 550451098658275358,
+	1285026984606433362: 550451098658275358,
+	550451099166048298:  550451098658275358,
+	1285332193299796028: 550451098658275358,
+	1285024907868241961: 550451098658275358,
+	934484018207006750:  550451098658275358,
+	1284604571662159872: 550451098658275358,
+	1198271989148815484: 550451098658275358,
+	1285022953481961473: 550451098658275358,
+	1148629417451597885: 550451098658275358,
+	1148629459503677601: 550451098658275358,
+	927666617620500541:  550451098658275358,
+	1280499340577607812: 550451098658275358,
+	802922022798753793:  550451098658275358,
+	1265322648074719264: 550451098658275358,
+	1286418129714548939: 550451098658275358,
+	1284998508897767555: 550451098658275358,
+	1286850367488790581: 550451098658275358,
+	1285346159346319472: 550451098658275358,
+	1284622169350864956: 550451098658275358,
+}
+
+// GuildChannelCategories maps the channels of every guild to their category, channels without category are absent
+var GuildChannelCategories = map[GuildChannelEnum]GuildCategoryChannelEnum{
+	550451099166048298:  550451099166048297,
+	1285332193299796028: 550451099166048297,
+	1285024907868241961: 550451099166048297,
+	934484018207006750:  550451099166048297,
+	1284604571662159872: 550451099166048297,
+	1198271989148815484: 927666503376076820,
+	1285022953481961473: 927666503376076820,
+	1148629417451597885: 927666503376076820,
+	1148629459503677601: 927666503376076820,
+	927666617620500541:  927666503376076820,
+	1280499340577607812: 802921893731762237,
+	802922022798753793:  802921893731762237,
+	1265322648074719264: 1242498003294748775,
+	1286418129714548939: 1242498003294748775,
+	1284998508897767555: 1284621862193729587,
+	1286850367488790581: 1284621862193729587,
+	1285346159346319472: 1284621862193729587,
+	1284622169350864956: 1284621862193729587,
 }
 
 // Guild Category Channels functions
 
 func (e GuildCategoryChannelEnum) String() string {
-	return string(e)
+	return GuildCategoryChannelNames[e]
 }
 
 func (e GuildCategoryChannelEnum) ID() snowflake.ID {
-	return GuildCategoryChannelMap[e]
+	return snowflake.ID(e)
+}
+
+func (e GuildCategoryChannelEnum) GuildID() snowflake.ID {
+	return GuildChannelGuilds[e.ID()]
 }
 
 func (e GuildCategoryChannelEnum) IsValid() bool {
-	_, ok := GuildCategoryChannelMap[e]
+	_, ok := GuildCategoryChannelNames[e]
 	return ok
 }
 
+// Mention returns the mention of the category to use in messages
+func (e GuildCategoryChannelEnum) Mention() string {
+	return discord.ChannelMention(e.ID())
+}
+
+// GetChannels returns the channels of the category, in server order
 func (e GuildCategoryChannelEnum) GetChannels() []GuildChannelEnum {
-	var channels []GuildChannelEnum
-	for channel, parentID := range GuildChannelCategoryMap {
-		if parentID == e.ID() {
-			channels = append(channels, channel)
+	for _, tree := range guildChannelTrees[e.GuildID()] {
+		if tree.Category == e {
+			return slices.Clone(tree.Channels)
 		}
 	}
-	return channels
+	return nil
+}
+
+// MarshalText writes the category as its ID
+func (e GuildCategoryChannelEnum) MarshalText() ([]byte, error) {
+	return []byte(e.ID().String()), nil
+}
+
+// UnmarshalText reads a category from its ID or its Go expression, e.g. GalaxyCategories.QuartiersSecurises
+func (e *GuildCategoryChannelEnum) UnmarshalText(text []byte) error {
+	id, err := parseChannel(text)
+	if err != nil || !GuildCategoryChannelEnum(id).IsValid() {
+		return fmt.Errorf("unknown category '%s'", text)
+	}
+	*e = GuildCategoryChannelEnum(id)
+	return nil
+}
+
+// AllGuildCategoryChannels returns the categories of every guild, guild by guild, in server order
+func AllGuildCategoryChannels() []GuildCategoryChannelEnum {
+	var categories []GuildCategoryChannelEnum
+	for _, guildID := range channelGuilds {
+		for _, tree := range guildChannelTrees[guildID] {
+			if tree.Category != 0 {
+				categories = append(categories, tree.Category)
+			}
+		}
+	}
+	return categories
+}
+
+// GuildCategoryChannelFromID returns the category having the ID, or false if there is none
+func GuildCategoryChannelFromID(id snowflake.ID) (GuildCategoryChannelEnum, bool) {
+	return GuildCategoryChannelEnum(id), GuildCategoryChannelEnum(id).IsValid()
+}
+
+// GuildCategoryChannelFromName returns the first category of the guild having the name, or false if there is none
+func GuildCategoryChannelFromName(guildID snowflake.ID, name string) (GuildCategoryChannelEnum, bool) {
+	for _, tree := range guildChannelTrees[guildID] {
+		if tree.Category != 0 && tree.Category.String() == name {
+			return tree.Category, true
+		}
+	}
+	return 0, false
 }
 
 // Guild Channels functions
 
 func (e GuildChannelEnum) String() string {
-	return string(e)
+	return GuildChannelNames[e]
 }
 
 func (e GuildChannelEnum) ID() snowflake.ID {
-	return GuildChannelMap[e]
+	return snowflake.ID(e)
+}
+
+func (e GuildChannelEnum) GuildID() snowflake.ID {
+	return GuildChannelGuilds[e.ID()]
 }
 
 func (e GuildChannelEnum) IsValid() bool {
-	_, ok := GuildChannelMap[e]
+	_, ok := GuildChannelNames[e]
 	return ok
 }
 
+// ParentID returns the ID of the category of the channel, or 0 if it has none
 func (e GuildChannelEnum) ParentID() snowflake.ID {
-	return GuildChannelCategoryMap[e]
+	return GuildChannelCategories[e].ID()
+}
+
+// Category returns the category of the channel, or 0 if it has none
+func (e GuildChannelEnum) Category() GuildCategoryChannelEnum {
+	return GuildChannelCategories[e]
+}
+
+// Mention returns the mention of the channel to use in messages
+func (e GuildChannelEnum) Mention() string {
+	return discord.ChannelMention(e.ID())
+}
+
+// MarshalText writes the channel as its ID
+func (e GuildChannelEnum) MarshalText() ([]byte, error) {
+	return []byte(e.ID().String()), nil
+}
+
+// UnmarshalText reads a channel from its ID or its Go expression, e.g. GalaxyChannels.Laboratoire
+func (e *GuildChannelEnum) UnmarshalText(text []byte) error {
+	id, err := parseChannel(text)
+	if err != nil || !GuildChannelEnum(id).IsValid() {
+		return fmt.Errorf("unknown channel '%s'", text)
+	}
+	*e = GuildChannelEnum(id)
+	return nil
+}
+
+// AllGuildChannels returns the channels of every guild, guild by guild, in server order
+func AllGuildChannels() []GuildChannelEnum {
+	var channels []GuildChannelEnum
+	for _, guildID := range channelGuilds {
+		for _, tree := range guildChannelTrees[guildID] {
+			channels = append(channels, tree.Channels...)
+		}
+	}
+	return channels
+}
+
+// GuildChannelFromID returns the channel having the ID, or false if there is none
+func GuildChannelFromID(id snowflake.ID) (GuildChannelEnum, bool) {
+	return GuildChannelEnum(id), GuildChannelEnum(id).IsValid()
+}
+
+// GuildChannelFromName returns the first channel of the guild having the name, in server order, or false if there is none
+func GuildChannelFromName(guildID snowflake.ID, name string) (GuildChannelEnum, bool) {
+	for _, tree := range guildChannelTrees[guildID] {
+		for _, channel := range tree.Channels {
+			if channel.String() == name {
+				return channel, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// GuildChannelTree returns the categories of the guild with their channels, in server order.
+// The channels without category come first, in a tree whose category is 0
+func GuildChannelTree(guildID snowflake.ID) []GuildCategoryTree {
+	trees := slices.Clone(guildChannelTrees[guildID])
+	for i := range trees {
+		trees[i].Channels = slices.Clone(trees[i].Channels)
+	}
+	return trees
+}
+
+// parseChannel reads the ID of a category or channel from its ID or its Go expression
+func parseChannel(text []byte) (snowflake.ID, error) {
+	if id, ok := channelExpressions[string(text)]; ok {
+		return id, nil
+	}
+	return snowflake.Parse(string(text))
 }
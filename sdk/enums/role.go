@@ -4,50 +4,166 @@
 package enums
 
 import (
+	"fmt"
+	"slices"
+
+	"github.com/disgoorg/disgo/discord"
 	"github.com/disgoorg/snowflake/v2"
 )
 
-type RoleEnum string
-
-const (
-	RoleCapitaine                RoleEnum = "Capitaine"
-	RoleIntelligenceArtificielle RoleEnum = "Intelligence Artificielle"
-	RoleQuinn                    RoleEnum = "Quinn"
-	RoleAdjointDuCapitaine       RoleEnum = "Adjoint du Capitaine"
-	RoleGardienDesCookies        RoleEnum = "Gardien des Cookies"
-	RoleMembreDequipage          RoleEnum = "Membre d’équipage"
-	RoleRecrue                   RoleEnum = "Recrue"
-	RoleExplorateur              RoleEnum = "Explorateur"
-	RoleIngenieur                RoleEnum = "Ingénieur"
-	RolePrisonnierDuMepris       RoleEnum = "Prisonnier du Mépris"
-	RoleFilsDInvictus            RoleEnum = "Fils d’Invictus"
-	RoleEveryone                 RoleEnum = "@everyone"
-)
+// RoleEnum is a role of one of the guilds, identified by its ID
+type RoleEnum snowflake.ID
+
+// GalaxyRoles are the roles of the guild 'Galaxy'
+var GalaxyRoles = struct {
+	Capitaine                RoleEnum
+	IntelligenceArtificielle RoleEnum
+	Quinn                    RoleEnum
+	AdjointDuCapitaine       RoleEnum
+	GardienDesCookies        RoleEnum
+	MembreDequipage          RoleEnum
+	Recrue                   RoleEnum
+	Explorateur              RoleEnum
+	Ingenieur                RoleEnum
+	PrisonnierDuMepris       RoleEnum
+	FilsDInvictus            RoleEnum
+	Everyone                 RoleEnum
+}{
+	Capitaine:                1284597292459888824,
+	IntelligenceArtificielle: 1286850619801604148,
+	Quinn:                    1285051433699508368,
+	AdjointDuCapitaine:       1284597706706128906,
+	GardienDesCookies:        1248347987353272503,
+	MembreDequipage:          1284597855931203756,
+	Recrue:                   1284598017839595632,
+	Explorateur:              1285350867331190825,
+	Ingenieur:                1285351118515339274,
+	PrisonnierDuMepris:       1285374094673580042,
+	FilsDInvictus:            1284909117399502918,
+	Everyone:                 550451098658275358,
+}
+
+// roles are the roles of every guild, guild by guild, from the highest to the lowest
+var roles = []RoleEnum{
+	1284597292459888824,
+	1286850619801604148,
+	1285051433699508368,
+	1284597706706128906,
+	1248347987353272503,
+	1284597855931203756,
+	1284598017839595632,
+	1285350867331190825,
+	1285351118515339274,
+	1285374094673580042,
+	1284909117399502918,
+	550451098658275358,
+}
 
-var RoleMap = map[RoleEnum]snowflake.ID{
-	RoleCapitaine:                1284597292459888824,
-	RoleIntelligenceArtificielle: 1286850619801604148,
-	RoleQuinn:                    1285051433699508368,
-	RoleAdjointDuCapitaine:       1284597706706128906,
-	RoleGardienDesCookies:        1248347987353272503,
-	RoleMembreDequipage:          1284597855931203756,
-	RoleRecrue:                   1284598017839595632,
-	RoleExplorateur:              1285350867331190825,
-	RoleIngenieur:                1285351118515339274,
-	RolePrisonnierDuMepris:       1285374094673580042,
-	RoleFilsDInvictus:            1284909117399502918,
-	RoleEveryone:                 550451098658275358,
+// roleExpressions maps the Go expressions of the roles, e.g. GalaxyRoles.Capitaine, to the roles
+var roleExpressions = map[string]RoleEnum{
+	"GalaxyRoles.Capitaine":                1284597292459888824,
+	"GalaxyRoles.IntelligenceArtificielle": 1286850619801604148,
+	"GalaxyRoles.Quinn":                    1285051433699508368,
+	"GalaxyRoles.AdjointDuCapitaine":       1284597706706128906,
+	"GalaxyRoles.GardienDesCookies":        1248347987353272503,
+	"GalaxyRoles.MembreDequipage":          1284597855931203756,
+	"GalaxyRoles.Recrue":                   1284598017839595632,
+	"GalaxyRoles.Explorateur":              1285350867331190825,
+	"GalaxyRoles.Ingenieur":                1285351118515339274,
+	"GalaxyRoles.PrisonnierDuMepris":       1285374094673580042,
+	"GalaxyRoles.FilsDInvictus":            1284909117399502918,
+	"GalaxyRoles.Everyone":                 550451098658275358,
+}
+
+// RoleNames maps the roles of every guild to their name
+var RoleNames = map[RoleEnum]string{
+	1284597292459888824: "Capitaine",
+	1286850619801604148: "Intelligence Artificielle",
+	1285051433699508368: "Quinn",
+	1284597706706128906: "Adjoint du Capitaine",
+	1248347987353272503: "Gardien des Cookies",
+	1284597855931203756: "Membre d’équipage",
+	1284598017839595632: "Recrue",
+	1285350867331190825: "Explorateur",
+	1285351118515339274: "Ingénieur",
+	1285374094673580042: "Prisonnier du Mépris",
+	1284909117399502918: "Fils d’Invictus",
+	550451098658275358:  "@everyone",
+}
+
+// RoleGuilds maps the roles of every guild to their guild ID
+var RoleGuilds = map[RoleEnum]snowflake.ID{
+	1284597292459888824: 550451098658275358,
+	1286850619801604148: 550451098658275358,
+	1285051433699508368: 550451098658275358,
+	1284597706706128906: 550451098658275358,
+	1248347987353272503: 550451098658275358,
+	1284597855931203756: 550451098658275358,
+	1284598017839595632: 550451098658275358,
+	1285350867331190825: 550451098658275358,
+	1285351118515339274: 550451098658275358,
+	1285374094673580042: 550451098658275358,
+	1284909117399502918: 550451098658275358,
+	550451098658275358:  550451098658275358,
 }
 
 func (e RoleEnum) String() string {
-	return string(e)
+	return RoleNames[e]
 }
 
 func (e RoleEnum) ID() snowflake.ID {
-	return RoleMap[e]
+	return snowflake.ID(e)
+}
+
+func (e RoleEnum) GuildID() snowflake.ID {
+	return RoleGuilds[e]
 }
 
 func (e RoleEnum) IsValid() bool {
-	_, ok := RoleMap[e]
+	_, ok := RoleNames[e]
 	return ok
 }
+
+// Mention returns the mention of the role to use in messages
+func (e RoleEnum) Mention() string {
+	return discord.RoleMention(e.ID())
+}
+
+// MarshalText writes the role as its ID
+func (e RoleEnum) MarshalText() ([]byte, error) {
+	return []byte(e.ID().String()), nil
+}
+
+// UnmarshalText reads a role from its ID or its Go expression, e.g. GalaxyRoles.Capitaine
+func (e *RoleEnum) UnmarshalText(text []byte) error {
+	if role, ok := roleExpressions[string(text)]; ok {
+		*e = role
+		return nil
+	}
+	id, err := snowflake.Parse(string(text))
+	if err != nil || !RoleEnum(id).IsValid() {
+		return fmt.Errorf("unknown role '%s'", text)
+	}
+	*e = RoleEnum(id)
+	return nil
+}
+
+// AllRoles returns the roles of every guild, guild by guild, from the highest to the lowest
+func AllRoles() []RoleEnum {
+	return slices.Clone(roles)
+}
+
+// RoleFromID returns the role having the ID, or false if there is none
+func RoleFromID(id snowflake.ID) (RoleEnum, bool) {
+	return RoleEnum(id), RoleEnum(id).IsValid()
+}
+
+// RoleFromName returns the highest role of the guild having the name, or false if there is none
+func RoleFromName(guildID snowflake.ID, name string) (RoleEnum, bool) {
+	for _, role := range roles {
+		if role.GuildID() == guildID && role.String() == name {
+			return role, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,201 @@
+package guards
+
+import (
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"github.com/bil0u/galaxy-os/sdk/enums"
+	"github.com/bil0u/galaxy-os/sdk/i18n"
+	"github.com/bil0u/galaxy-os/sdk/middlewares"
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/handler"
+	"github.com/disgoorg/snowflake/v2"
+)
+
+var (
+	deniedGuildOnlyMessage   = i18n.Declare("guards.denied.guild_only")
+	deniedRolesMessage       = i18n.Declare("guards.denied.roles")
+	deniedPermissionsMessage = i18n.Declare("guards.denied.permissions")
+	deniedChannelsMessage    = i18n.Declare("guards.denied.channels")
+	deniedCategoriesMessage  = i18n.Declare("guards.denied.categories")
+)
+
+// Denial describes why a guard refused an interaction
+type Denial struct {
+	Guard  string
+	Reason i18n.Message
+}
+
+// Guard checks whether an interaction is allowed, it returns nil if so or the reason of the denial
+type Guard func(e *handler.InteractionEvent) *Denial
+
+// Require is a middleware that runs the guards before the handler. If any guard
+// denies the interaction, the denial is logged and reported to the user as a forbidden error.
+// Denied autocomplete interactions are not logged, as they are sent on every keystroke
+func Require(guards ...Guard) handler.Middleware {
+	guard := All(guards...)
+	return func(next handler.Handler) handler.Handler {
+		return func(e *handler.InteractionEvent) error {
+			if denial := guard(e); denial != nil {
+				if e.Type() != discord.InteractionTypeAutocomplete {
+					logDenial(e, denial)
+				}
+				return middlewares.NewForbiddenError(denial.Reason)
+			}
+			return next(e)
+		}
+	}
+}
+
+// Check runs the guards against an interaction, without replying nor logging
+func Check(e *handler.InteractionEvent, guards ...Guard) *Denial {
+	return All(guards...)(e)
+}
+
+func logDenial(e *handler.InteractionEvent, denial *Denial) {
+	attrs := []any{
+		slog.String("audit", "access_denied"),
+		slog.String("guard", denial.Guard),
+		slog.String("reason", string(denial.Reason.Key)),
+		slog.String("user_id", e.User().ID.String()),
+		slog.String("user", e.User().Username),
+		slog.String("channel_id", e.Channel().ID().String()),
+		slog.String("interaction_id", e.ID().String()),
+	}
+	if e.GuildID() != nil {
+		attrs = append(attrs, slog.String("guild_id", e.GuildID().String()))
+	}
+	if path := middlewares.InteractionPath(e); path != "" {
+		attrs = append(attrs, slog.String("path", path))
+	}
+	slog.Warn("Access denied", attrs...)
+}
+
+// All allows the interaction if every guard allows it, the first denial is returned otherwise
+func All(guards ...Guard) Guard {
+	return func(e *handler.InteractionEvent) *Denial {
+		for _, guard := range guards {
+			if denial := guard(e); denial != nil {
+				return denial
+			}
+		}
+		return nil
+	}
+}
+
+// Any allows the interaction if at least one guard allows it, the first denial is returned otherwise
+func Any(guards ...Guard) Guard {
+	return func(e *handler.InteractionEvent) *Denial {
+		var first *Denial
+		for _, guard := range guards {
+			denial := guard(e)
+			if denial == nil {
+				return nil
+			}
+			if first == nil {
+				first = denial
+			}
+		}
+		return first
+	}
+}
+
+// GuildOnly allows interactions made in a guild
+func GuildOnly() Guard {
+	return func(e *handler.InteractionEvent) *Denial {
+		if e.Member() == nil {
+			return &Denial{Guard: "guild_only", Reason: deniedGuildOnlyMessage.With(nil)}
+		}
+		return nil
+	}
+}
+
+// HasAnyRole allows members having at least one of the roles
+func HasAnyRole(roles ...enums.RoleEnum) Guard {
+	ids := make([]snowflake.ID, len(roles))
+	for i, role := range roles {
+		ids[i] = role.ID()
+	}
+	return HasAnyRoleID(ids...)
+}
+
+// HasAnyRoleID allows members having at least one of the roles, given by ID
+func HasAnyRoleID(roleIDs ...snowflake.ID) Guard {
+	return func(e *handler.InteractionEvent) *Denial {
+		if member := e.Member(); member != nil {
+			for _, roleID := range roleIDs {
+				if slices.Contains(member.RoleIDs, roleID) {
+					return nil
+				}
+			}
+		}
+		mentions := make([]string, len(roleIDs))
+		for i, roleID := range roleIDs {
+			mentions[i] = discord.RoleMention(roleID)
+		}
+		return &Denial{
+			Guard:  "has_any_role",
+			Reason: deniedRolesMessage.With(i18n.Vars{"roles": strings.Join(mentions, ", ")}),
+		}
+	}
+}
+
+// HasPermissions allows members having all the permissions in the channel of the interaction
+func HasPermissions(permissions discord.Permissions) Guard {
+	return func(e *handler.InteractionEvent) *Denial {
+		if member := e.Member(); member != nil && member.Permissions.Has(permissions) {
+			return nil
+		}
+		return &Denial{
+			Guard:  "has_permissions",
+			Reason: deniedPermissionsMessage.With(i18n.Vars{"permissions": permissions.String()}),
+		}
+	}
+}
+
+// InChannel allows interactions made in one of the channels
+func InChannel(channels ...enums.GuildChannelEnum) Guard {
+	return func(e *handler.InteractionEvent) *Denial {
+		channelID := e.Channel().ID()
+		for _, channel := range channels {
+			if channel.ID() == channelID {
+				return nil
+			}
+		}
+		return &Denial{
+			Guard:  "in_channel",
+			Reason: deniedChannelsMessage.With(i18n.Vars{"channels": channelMentions(channels)}),
+		}
+	}
+}
+
+// InCategory allows interactions made in a channel of one of the categories
+func InCategory(categories ...enums.GuildCategoryChannelEnum) Guard {
+	return func(e *handler.InteractionEvent) *Denial {
+		if channel, ok := e.Channel().MessageChannel.(discord.GuildChannel); ok && channel.ParentID() != nil {
+			for _, category := range categories {
+				if category.ID() == *channel.ParentID() {
+					return nil
+				}
+			}
+		}
+		names := make([]string, len(categories))
+		for i, category := range categories {
+			names[i] = fmt.Sprintf("**%s**", category.String())
+		}
+		return &Denial{
+			Guard:  "in_category",
+			Reason: deniedCategoriesMessage.With(i18n.Vars{"categories": strings.Join(names, ", ")}),
+		}
+	}
+}
+
+func channelMentions(channels []enums.GuildChannelEnum) string {
+	mentions := make([]string, len(channels))
+	for i, channel := range channels {
+		mentions[i] = discord.ChannelMention(channel.ID())
+	}
+	return strings.Join(mentions, ", ")
+}
@@ -0,0 +1,38 @@
+package sdk
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// ServeHealth starts an HTTP server answering on /health with the diagnostics of the bot,
+// with a 503 status while the gateway is not ready
+func (b *Bot) ServeHealth(address string) *http.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /health", func(w http.ResponseWriter, _ *http.Request) {
+		diagnostics := b.Diagnostics()
+		w.Header().Set("Content-Type", "application/json")
+		if !diagnostics.Ready() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		if err := json.NewEncoder(w).Encode(diagnostics); err != nil {
+			slog.Error("Failed to write health response", slog.Any("err", err))
+		}
+	})
+
+	server := &http.Server{
+		Addr:              address,
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+	go func() {
+		slog.Info("Serving health endpoint", slog.String("address", address))
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			slog.Error("Health endpoint stopped", slog.Any("err", err))
+		}
+	}()
+	return server
+}
@@ -0,0 +1,232 @@
+package help
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/bil0u/galaxy-os/sdk/guards"
+	"github.com/bil0u/galaxy-os/sdk/i18n"
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/handler"
+)
+
+var (
+	otherModule      = i18n.Declare("help.no_module")
+	optionalLabel    = i18n.Declare("help.optional")
+	examplesLabel    = i18n.Declare("help.examples")
+	userCommandLabel = i18n.Declare("help.user_command")
+	messageLabel     = i18n.Declare("help.message_command")
+)
+
+// Meta describes a command in the help, besides what its definition already tells
+type Meta struct {
+	// Module groups the commands in the help, commands without a module are listed last
+	Module i18n.Key
+	// Examples is an optional catalog message, with one example per line
+	Examples i18n.Key
+	// Guards are the guards protecting the routes of the command, users they deny don't see it
+	Guards []guards.Guard
+}
+
+// Index holds the help metadata of the commands of a bot, by command route
+type Index struct {
+	mu   sync.RWMutex
+	meta map[string]Meta
+}
+
+func NewIndex() *Index {
+	return &Index{meta: map[string]Meta{}}
+}
+
+// Describe sets the help metadata of the commands, given by their route, e.g. "/test"
+func (i *Index) Describe(meta Meta, paths ...string) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	for _, path := range paths {
+		i.meta[path] = meta
+	}
+}
+
+// Meta returns the help metadata of the command having the given default name
+func (i *Index) Meta(name string) (Meta, bool) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	meta, ok := i.meta["/"+name]
+	return meta, ok
+}
+
+// command is the part of a command definition shown in the help, read from its JSON
+// so that every kind of command and option is handled the same way
+type command struct {
+	Type                     discord.ApplicationCommandType `json:"type"`
+	Name                     string                         `json:"name"`
+	NameLocalizations        map[discord.Locale]string      `json:"name_localizations"`
+	Description              string                         `json:"description"`
+	DescriptionLocalizations map[discord.Locale]string      `json:"description_localizations"`
+	Options                  []option                       `json:"options"`
+	DefaultMemberPermissions *discord.Permissions           `json:"default_member_permissions"`
+}
+
+type option struct {
+	Type                     discord.ApplicationCommandOptionType `json:"type"`
+	Name                     string                               `json:"name"`
+	NameLocalizations        map[discord.Locale]string            `json:"name_localizations"`
+	Description              string                               `json:"description"`
+	DescriptionLocalizations map[discord.Locale]string            `json:"description_localizations"`
+	Required                 bool                                 `json:"required"`
+	Options                  []option                             `json:"options"`
+}
+
+func parseCommand(create discord.ApplicationCommandCreate) (command, error) {
+	var c command
+	data, err := json.Marshal(create)
+	if err != nil {
+		return c, err
+	}
+	if err := json.Unmarshal(data, &c); err != nil {
+		return c, fmt.Errorf("failed to read command '%s': %w", create.CommandName(), err)
+	}
+	return c, nil
+}
+
+// Visible returns the commands the user of the interaction can use, according to
+// their default member permissions and to the guards of their routes
+func (i *Index) Visible(e *handler.InteractionEvent, commands []discord.ApplicationCommandCreate) ([]discord.ApplicationCommandCreate, error) {
+	var visible []discord.ApplicationCommandCreate
+	for _, create := range commands {
+		c, err := parseCommand(create)
+		if err != nil {
+			return nil, err
+		}
+		if c.DefaultMemberPermissions != nil && *c.DefaultMemberPermissions != discord.PermissionsNone {
+			if member := e.Member(); member == nil || !member.Permissions.Has(*c.DefaultMemberPermissions) {
+				continue
+			}
+		}
+		if meta, ok := i.Meta(c.Name); ok && guards.Check(e, meta.Guards...) != nil {
+			continue
+		}
+		visible = append(visible, create)
+	}
+	return visible, nil
+}
+
+// entry is a command or a subcommand in the help
+type entry struct {
+	module  string
+	command string
+	text    string
+}
+
+// Lines renders the commands grouped by module, each module starting with its title,
+// names and descriptions are shown in the given locale
+func (i *Index) Lines(locale discord.Locale, commands []discord.ApplicationCommandCreate) ([]string, error) {
+	var entries []entry
+	for _, create := range commands {
+		c, err := parseCommand(create)
+		if err != nil {
+			return nil, err
+		}
+		meta, _ := i.Meta(c.Name)
+		entries = append(entries, commandEntries(locale, c, meta)...)
+	}
+
+	// Modules are sorted by title, except for the commands without a module which come last.
+	// The subcommands of a command keep their order
+	other := otherModule.T(locale)
+	slices.SortStableFunc(entries, func(a, b entry) int {
+		if (a.module == other) != (b.module == other) {
+			if a.module == other {
+				return 1
+			}
+			return -1
+		}
+		return cmp.Or(cmp.Compare(a.module, b.module), cmp.Compare(a.command, b.command))
+	})
+
+	var lines []string
+	for n, e := range entries {
+		if n == 0 || e.module != entries[n-1].module {
+			lines = append(lines, fmt.Sprintf("__**%s**__", e.module))
+		}
+		lines = append(lines, e.text)
+	}
+	return lines, nil
+}
+
+// commandEntries returns the entries of a command, one per subcommand
+func commandEntries(locale discord.Locale, c command, meta Meta) []entry {
+	module := moduleTitle(locale, meta.Module)
+	name := localized(locale, c.Name, c.NameLocalizations)
+
+	switch c.Type {
+	case discord.ApplicationCommandTypeUser:
+		return []entry{{module: module, command: name, text: fmt.Sprintf("**%s** (%s)", name, userCommandLabel.T(locale))}}
+	case discord.ApplicationCommandTypeMessage:
+		return []entry{{module: module, command: name, text: fmt.Sprintf("**%s** (%s)", name, messageLabel.T(locale))}}
+	}
+
+	var examples string
+	if meta.Examples != "" {
+		var lines []string
+		for _, example := range strings.Split(meta.Examples.T(locale), "\n") {
+			lines = append(lines, fmt.Sprintf("`%s`", example))
+		}
+		examples = fmt.Sprintf("\n> %s: %s", examplesLabel.T(locale), strings.Join(lines, ", "))
+	}
+
+	var entries []entry
+	var walk func(path []string, description string, descriptions map[discord.Locale]string, options []option)
+	walk = func(path []string, description string, descriptions map[discord.Locale]string, options []option) {
+		var args []option
+		for _, o := range options {
+			if o.Type == discord.ApplicationCommandOptionTypeSubCommand || o.Type == discord.ApplicationCommandOptionTypeSubCommandGroup {
+				walk(append(slices.Clone(path), localized(locale, o.Name, o.NameLocalizations)), o.Description, o.DescriptionLocalizations, o.Options)
+				continue
+			}
+			args = append(args, o)
+		}
+		// Commands with subcommands can't be used directly
+		if len(args) < len(options) {
+			return
+		}
+		full := "/" + strings.Join(path, " ")
+		lines := []string{fmt.Sprintf("**%s** — %s", full, localized(locale, description, descriptions))}
+		for _, o := range args {
+			line := fmt.Sprintf("- `%s`: %s", localized(locale, o.Name, o.NameLocalizations), localized(locale, o.Description, o.DescriptionLocalizations))
+			if !o.Required {
+				line += fmt.Sprintf(" (%s)", optionalLabel.T(locale))
+			}
+			lines = append(lines, line)
+		}
+		entries = append(entries, entry{module: module, command: name, text: strings.Join(lines, "\n")})
+	}
+	walk([]string{name}, c.Description, c.DescriptionLocalizations, c.Options)
+
+	// The examples belong to the whole command, they follow its last subcommand
+	if examples != "" && len(entries) > 0 {
+		entries[len(entries)-1].text += examples
+	}
+	return entries
+}
+
+func moduleTitle(locale discord.Locale, module i18n.Key) string {
+	if module == "" {
+		return otherModule.T(locale)
+	}
+	return module.T(locale)
+}
+
+// localized returns the localization of a value following the fallback chain of the locale
+func localized(locale discord.Locale, value string, localizations map[discord.Locale]string) string {
+	for _, l := range i18n.Fallbacks(locale) {
+		if text, ok := localizations[l]; ok {
+			return text
+		}
+	}
+	return value
+}
@@ -0,0 +1,164 @@
+package i18n
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/pelletier/go-toml/v2"
+	"golang.org/x/text/feature/plural"
+)
+
+var pluralForms = map[string]plural.Form{
+	"zero":  plural.Zero,
+	"one":   plural.One,
+	"two":   plural.Two,
+	"few":   plural.Few,
+	"many":  plural.Many,
+	"other": plural.Other,
+}
+
+// Entry is a single translated message, with optional plural forms
+type Entry struct {
+	Text  string
+	Forms map[plural.Form]string
+}
+
+// IsPlural returns true if the entry has plural forms
+func (e Entry) IsPlural() bool {
+	return len(e.Forms) > 0
+}
+
+// Texts returns every text of the entry, plural forms included
+func (e Entry) Texts() []string {
+	if !e.IsPlural() {
+		return []string{e.Text}
+	}
+	texts := make([]string, 0, len(e.Forms))
+	for _, text := range e.Forms {
+		texts = append(texts, text)
+	}
+	sort.Strings(texts)
+	return texts
+}
+
+// Catalog holds the messages of a single locale, keyed by message ID
+type Catalog struct {
+	Locale  discord.Locale
+	Entries map[string]Entry
+}
+
+// Keys returns the sorted message IDs of the catalog
+func (c *Catalog) Keys() []string {
+	keys := make([]string, 0, len(c.Entries))
+	for key := range c.Entries {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// LoadCatalogs loads every catalog found in the given directory of fsys.
+// Files must be named after the locale they hold, e.g. "fr.toml" or "en-US.json"
+func LoadCatalogs(fsys fs.FS, dir string) (map[discord.Locale]*Catalog, error) {
+	files, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read catalogs directory: %w", err)
+	}
+
+	catalogs := map[discord.Locale]*Catalog{}
+	for _, file := range files {
+		if file.IsDir() {
+			continue
+		}
+		ext := path.Ext(file.Name())
+		locale := discord.Locale(strings.TrimSuffix(file.Name(), ext))
+		if _, ok := discord.Locales[locale]; !ok {
+			return nil, fmt.Errorf("catalog '%s' is not named after a discord locale", file.Name())
+		}
+
+		raw, err := fs.ReadFile(fsys, path.Join(dir, file.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read catalog '%s': %w", file.Name(), err)
+		}
+
+		var data map[string]any
+		switch ext {
+		case ".toml":
+			err = toml.Unmarshal(raw, &data)
+		case ".json":
+			err = json.Unmarshal(raw, &data)
+		default:
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse catalog '%s': %w", file.Name(), err)
+		}
+
+		catalog, ok := catalogs[locale]
+		if !ok {
+			catalog = &Catalog{Locale: locale, Entries: map[string]Entry{}}
+			catalogs[locale] = catalog
+		}
+		if err := catalog.add("", data); err != nil {
+			return nil, fmt.Errorf("invalid catalog '%s': %w", file.Name(), err)
+		}
+	}
+	return catalogs, nil
+}
+
+// add flattens nested tables into dotted message IDs
+func (c *Catalog) add(prefix string, data map[string]any) error {
+	for name, value := range data {
+		key := name
+		if prefix != "" {
+			key = prefix + "." + name
+		}
+		switch v := value.(type) {
+		case string:
+			if _, exists := c.Entries[key]; exists {
+				return fmt.Errorf("duplicate message '%s'", key)
+			}
+			c.Entries[key] = Entry{Text: v}
+		case map[string]any:
+			if forms, ok := parsePluralForms(v); ok {
+				if _, exists := c.Entries[key]; exists {
+					return fmt.Errorf("duplicate message '%s'", key)
+				}
+				c.Entries[key] = Entry{Text: forms[plural.Other], Forms: forms}
+				continue
+			}
+			if err := c.add(key, v); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("message '%s' must be a string or a table, got %T", key, value)
+		}
+	}
+	return nil
+}
+
+// parsePluralForms returns the plural forms of a table, if it only contains plural
+// categories (zero, one, two, few, many, other) with at least "other"
+func parsePluralForms(data map[string]any) (map[plural.Form]string, bool) {
+	if _, ok := data["other"]; !ok {
+		return nil, false
+	}
+	forms := map[plural.Form]string{}
+	for name, value := range data {
+		form, ok := pluralForms[name]
+		if !ok {
+			return nil, false
+		}
+		text, ok := value.(string)
+		if !ok {
+			return nil, false
+		}
+		forms[form] = text
+	}
+	return forms, true
+}
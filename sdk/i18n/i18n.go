@@ -0,0 +1,242 @@
+package i18n
+
+import (
+	"embed"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/disgoorg/disgo/discord"
+	"golang.org/x/text/feature/plural"
+	"golang.org/x/text/language"
+)
+
+// DefaultLocale is the locale used when a message is missing in the requested one
+const DefaultLocale = discord.LocaleEnglishUS
+
+// CountVar is the placeholder used to pick the plural form of a message
+const CountVar = "count"
+
+//go:embed locales
+var localesFS embed.FS
+
+var catalogs = mustLoadCatalogs()
+
+func mustLoadCatalogs() map[discord.Locale]*Catalog {
+	c, err := LoadCatalogs(localesFS, "locales")
+	if err != nil {
+		panic(fmt.Sprintf("failed to load embedded catalogs: %v", err))
+	}
+	if _, ok := c[DefaultLocale]; !ok {
+		panic(fmt.Sprintf("missing catalog for default locale '%s'", DefaultLocale))
+	}
+	return c
+}
+
+// Catalogs returns the embedded catalogs, keyed by locale
+func Catalogs() map[discord.Locale]*Catalog {
+	return catalogs
+}
+
+// Locales returns the locales having a catalog, the default locale first
+func Locales() []discord.Locale {
+	locales := []discord.Locale{DefaultLocale}
+	for locale := range catalogs {
+		if locale != DefaultLocale {
+			locales = append(locales, locale)
+		}
+	}
+	sort.Slice(locales[1:], func(i, j int) bool { return locales[i+1] < locales[j+1] })
+	return locales
+}
+
+// Fallbacks returns the locales to try, in order, when looking up a message
+func Fallbacks(locale discord.Locale) []discord.Locale {
+	chain := []discord.Locale{locale}
+	// Regional variants fall back to the base language if it has a catalog, e.g. en-GB -> en
+	if base, _, found := strings.Cut(locale.Code(), "-"); found {
+		if _, ok := catalogs[discord.Locale(base)]; ok {
+			chain = append(chain, discord.Locale(base))
+		}
+	}
+	if locale != DefaultLocale {
+		chain = append(chain, DefaultLocale)
+	}
+	return chain
+}
+
+// Vars holds the values of the named placeholders of a message, e.g. {name}
+type Vars map[string]any
+
+var placeholderRegex = regexp.MustCompile(`\{([a-zA-Z0-9_]+)\}`)
+
+// Placeholders returns the sorted names of the placeholders used in a text
+func Placeholders(text string) []string {
+	seen := map[string]bool{}
+	var names []string
+	for _, match := range placeholderRegex.FindAllStringSubmatch(text, -1) {
+		if !seen[match[1]] {
+			seen[match[1]] = true
+			names = append(names, match[1])
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func interpolate(text string, vars Vars) string {
+	if len(vars) == 0 {
+		return text
+	}
+	return placeholderRegex.ReplaceAllStringFunc(text, func(match string) string {
+		if value, ok := vars[match[1:len(match)-1]]; ok {
+			return fmt.Sprint(value)
+		}
+		return match
+	})
+}
+
+func pluralForm(locale discord.Locale, vars Vars) plural.Form {
+	var count int
+	switch v := vars[CountVar].(type) {
+	case int:
+		count = v
+	case int64:
+		count = int(v)
+	case uint:
+		count = int(v)
+	default:
+		return plural.Other
+	}
+	if count < 0 {
+		count = -count
+	}
+	tag, err := language.Parse(locale.Code())
+	if err != nil {
+		return plural.Other
+	}
+	return plural.Cardinal.MatchPlural(tag, count, 0, 0, 0, 0)
+}
+
+func (e Entry) render(locale discord.Locale, vars Vars) string {
+	text := e.Text
+	if e.IsPlural() {
+		if form, ok := e.Forms[pluralForm(locale, vars)]; ok {
+			text = form
+		}
+	}
+	return interpolate(text, vars)
+}
+
+// Lookup returns the entry for a message in the given locale, without fallback
+func Lookup(locale discord.Locale, key string) (Entry, bool) {
+	catalog, ok := catalogs[locale]
+	if !ok {
+		return Entry{}, false
+	}
+	entry, ok := catalog.Entries[key]
+	return entry, ok
+}
+
+// T translates a message in the given locale, following the fallback chain.
+// The message ID itself is returned if no catalog has the message
+func T(locale discord.Locale, key string, vars ...Vars) string {
+	var merged Vars
+	if len(vars) == 1 {
+		merged = vars[0]
+	} else if len(vars) > 1 {
+		merged = Vars{}
+		for _, v := range vars {
+			for name, value := range v {
+				merged[name] = value
+			}
+		}
+	}
+
+	for _, l := range Fallbacks(locale) {
+		if entry, ok := Lookup(l, key); ok {
+			return entry.render(l, merged)
+		}
+	}
+	slog.Warn("No translation found for message", slog.String("key", key), slog.Any("locale", locale))
+	return key
+}
+
+var (
+	declaredKeys   = map[string]bool{}
+	declaredKeysMu sync.Mutex
+)
+
+// Key is the ID of a message in the catalogs
+type Key string
+
+// Declare returns the key of a message and records it as used,
+// which lets the catalogs linter report unused messages
+func Declare(key string) Key {
+	declaredKeysMu.Lock()
+	defer declaredKeysMu.Unlock()
+	declaredKeys[key] = true
+	return Key(key)
+}
+
+// DeclaredKeys returns the sorted message IDs declared so far
+func DeclaredKeys() []string {
+	declaredKeysMu.Lock()
+	defer declaredKeysMu.Unlock()
+	keys := make([]string, 0, len(declaredKeys))
+	for key := range declaredKeys {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// T translates the message in the given locale
+func (k Key) T(locale discord.Locale, vars ...Vars) string {
+	return T(locale, string(k), vars...)
+}
+
+// Default returns the message in the default locale
+func (k Key) Default(vars ...Vars) string {
+	return T(DefaultLocale, string(k), vars...)
+}
+
+// Localizations returns the message in every locale having it, as expected
+// by discord for command names and descriptions
+func (k Key) Localizations(vars ...Vars) map[discord.Locale]string {
+	Declare(string(k))
+	localizations := map[discord.Locale]string{}
+	for locale := range catalogs {
+		if _, ok := Lookup(locale, string(k)); ok {
+			localizations[locale] = T(locale, string(k), vars...)
+		}
+	}
+	if len(localizations) == 0 {
+		return nil
+	}
+	return localizations
+}
+
+// With binds placeholder values to the message, to translate it later
+func (k Key) With(vars Vars) Message {
+	return Message{Key: k, Vars: vars}
+}
+
+// Message is a message and its placeholder values, translated once the locale is known
+type Message struct {
+	Key  Key
+	Vars Vars
+}
+
+// IsZero returns true if no message is set
+func (m Message) IsZero() bool {
+	return m.Key == ""
+}
+
+// T translates the message in the given locale
+func (m Message) T(locale discord.Locale) string {
+	return m.Key.T(locale, m.Vars)
+}
@@ -0,0 +1,108 @@
+package i18n
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/disgoorg/disgo/discord"
+	"golang.org/x/text/feature/plural"
+)
+
+// useTestCatalogs replaces the embedded catalogs for the duration of a test. The base language "es"
+// is not a discord locale, so it can't be loaded from files, but the fallback chain still supports it
+func useTestCatalogs(t *testing.T) {
+	t.Helper()
+	previous := catalogs
+	catalogs = map[discord.Locale]*Catalog{
+		DefaultLocale: {Locale: DefaultLocale, Entries: map[string]Entry{
+			"greeting": {Text: "Hello {name}"},
+			"farewell": {Text: "Goodbye"},
+			"only_en":  {Text: "English only"},
+			"members": {Forms: map[plural.Form]string{
+				plural.One:   "{count} member",
+				plural.Other: "{count} members",
+			}},
+		}},
+		discord.LocaleFrench: {Locale: discord.LocaleFrench, Entries: map[string]Entry{
+			"greeting": {Text: "Bonjour {name}"},
+			"members": {Forms: map[plural.Form]string{
+				plural.One:   "{count} membre",
+				plural.Other: "{count} membres",
+			}},
+		}},
+		"es": {Locale: "es", Entries: map[string]Entry{
+			"greeting": {Text: "Hola {name}"},
+			"farewell": {Text: "Adiós"},
+		}},
+		discord.LocaleSpanishES: {Locale: discord.LocaleSpanishES, Entries: map[string]Entry{
+			"greeting": {Text: "Buenas {name}"},
+		}},
+	}
+	t.Cleanup(func() { catalogs = previous })
+}
+
+func TestFallbacks(t *testing.T) {
+	useTestCatalogs(t)
+	tests := []struct {
+		locale discord.Locale
+		want   []discord.Locale
+	}{
+		{DefaultLocale, []discord.Locale{DefaultLocale}},
+		{discord.LocaleFrench, []discord.Locale{discord.LocaleFrench, DefaultLocale}},
+		{discord.LocaleSpanishES, []discord.Locale{discord.LocaleSpanishES, "es", DefaultLocale}},
+		// Without a catalog for the base language, variants fall back to the default locale
+		{discord.LocaleEnglishGB, []discord.Locale{discord.LocaleEnglishGB, DefaultLocale}},
+	}
+	for _, tt := range tests {
+		if got := Fallbacks(tt.locale); !slices.Equal(got, tt.want) {
+			t.Errorf("Fallbacks(%s) = %v, want %v", tt.locale, got, tt.want)
+		}
+	}
+}
+
+func TestKeyT(t *testing.T) {
+	useTestCatalogs(t)
+	tests := []struct {
+		name   string
+		locale discord.Locale
+		key    Key
+		vars   []Vars
+		want   string
+	}{
+		{"locale", discord.LocaleFrench, "greeting", []Vars{{"name": "Ana"}}, "Bonjour Ana"},
+		{"regional variant", discord.LocaleSpanishES, "greeting", []Vars{{"name": "Ana"}}, "Buenas Ana"},
+		{"base language", discord.LocaleSpanishES, "farewell", nil, "Adiós"},
+		{"default locale", discord.LocaleSpanishES, "only_en", nil, "English only"},
+		{"unknown locale", discord.LocaleJapanese, "farewell", nil, "Goodbye"},
+		{"missing message", discord.LocaleFrench, "missing", nil, "missing"},
+		{"missing placeholder", discord.LocaleFrench, "greeting", nil, "Bonjour {name}"},
+		{"unknown placeholder", DefaultLocale, "greeting", []Vars{{"other": "Ana"}}, "Hello {name}"},
+		{"merged vars", DefaultLocale, "greeting", []Vars{{"name": "Ana"}, {"name": "Bea"}}, "Hello Bea"},
+		{"plural one", DefaultLocale, "members", []Vars{{CountVar: 1}}, "1 member"},
+		{"plural other", DefaultLocale, "members", []Vars{{CountVar: 5}}, "5 members"},
+		{"plural zero in english", DefaultLocale, "members", []Vars{{CountVar: 0}}, "0 members"},
+		{"plural zero in french", discord.LocaleFrench, "members", []Vars{{CountVar: 0}}, "0 membre"},
+		{"plural negative", DefaultLocale, "members", []Vars{{CountVar: int64(-1)}}, "-1 member"},
+		{"plural without count", DefaultLocale, "members", nil, "{count} members"},
+		{"plural with a non integer count", DefaultLocale, "members", []Vars{{CountVar: "1"}}, "1 members"},
+		{"plural falls back with its own rules", discord.LocaleSpanishES, "members", []Vars{{CountVar: 1}}, "1 member"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.key.T(tt.locale, tt.vars...); got != tt.want {
+				t.Errorf("T(%s, %s) = '%s', want '%s'", tt.locale, tt.key, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMessageT(t *testing.T) {
+	useTestCatalogs(t)
+	message := Key("greeting").With(Vars{"name": "Ana"})
+	if got := message.T(discord.LocaleFrench); got != "Bonjour Ana" {
+		t.Errorf("T(fr) = '%s', want 'Bonjour Ana'", got)
+	}
+	if got := message.T(discord.LocaleGerman); got != "Hello Ana" {
+		t.Errorf("T(de) = '%s', want 'Hello Ana'", got)
+	}
+}
@@ -0,0 +1,251 @@
+package i18n
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+// Discord limits for application commands
+const (
+	maxNameLength        = 32
+	maxDescriptionLength = 100
+	maxChoiceNameLength  = 100
+)
+
+var slashNameRegex = regexp.MustCompile(`^[-_\p{L}\p{N}\p{Devanagari}\p{Thai}]{1,32}$`)
+
+// IssueKind is the kind of problem found by the linter
+type IssueKind string
+
+const (
+	IssueMissingLocale       IssueKind = "missing-locale"
+	IssueUnusedKey           IssueKind = "unused-key"
+	IssuePlaceholderMismatch IssueKind = "placeholder-mismatch"
+	IssueLimit               IssueKind = "limit"
+)
+
+// Issue is a problem found by the linter
+type Issue struct {
+	Kind    IssueKind
+	Subject string
+	Message string
+}
+
+func (i Issue) String() string {
+	return fmt.Sprintf("[%s] %s: %s", i.Kind, i.Subject, i.Message)
+}
+
+// lintCommand mirrors the JSON representation of application commands,
+// which lets the linter walk every kind of command and option the same way
+type lintCommand struct {
+	Type                     discord.ApplicationCommandType `json:"type"`
+	Name                     string                         `json:"name"`
+	NameLocalizations        map[discord.Locale]string      `json:"name_localizations"`
+	Description              string                         `json:"description"`
+	DescriptionLocalizations map[discord.Locale]string      `json:"description_localizations"`
+	Options                  []lintOption                   `json:"options"`
+}
+
+type lintOption struct {
+	Name                     string                    `json:"name"`
+	NameLocalizations        map[discord.Locale]string `json:"name_localizations"`
+	Description              string                    `json:"description"`
+	DescriptionLocalizations map[discord.Locale]string `json:"description_localizations"`
+	Choices                  []lintChoice              `json:"choices"`
+	Options                  []lintOption              `json:"options"`
+}
+
+type lintChoice struct {
+	Name              string                    `json:"name"`
+	NameLocalizations map[discord.Locale]string `json:"name_localizations"`
+}
+
+// Lint checks the translations of the given commands and of the embedded catalogs
+func Lint(commands []discord.ApplicationCommandCreate) []Issue {
+	var issues []Issue
+	locales := Locales()
+
+	seen := map[string]bool{}
+	for _, command := range commands {
+		raw, err := json.Marshal(command)
+		if err != nil {
+			issues = append(issues, Issue{Kind: IssueLimit, Subject: command.CommandName(), Message: fmt.Sprintf("failed to marshal command: %v", err)})
+			continue
+		}
+		var c lintCommand
+		if err := json.Unmarshal(raw, &c); err != nil {
+			issues = append(issues, Issue{Kind: IssueLimit, Subject: command.CommandName(), Message: fmt.Sprintf("failed to read command: %v", err)})
+			continue
+		}
+		subject := "/" + c.Name
+		if c.Type != discord.ApplicationCommandTypeSlash {
+			subject = c.Name
+		}
+		if seen[subject] {
+			continue
+		}
+		seen[subject] = true
+		issues = append(issues, lintCommandTexts(subject, c, locales)...)
+	}
+
+	issues = append(issues, lintCatalogs(locales)...)
+	return issues
+}
+
+func lintCommandTexts(subject string, c lintCommand, locales []discord.Locale) []Issue {
+	slash := c.Type == discord.ApplicationCommandTypeSlash
+	issues := lintName(subject, c.Name, c.NameLocalizations, locales, slash)
+	if slash {
+		issues = append(issues, lintText(subject+" description", c.Description, c.DescriptionLocalizations, locales, maxDescriptionLength)...)
+	}
+	for _, option := range c.Options {
+		issues = append(issues, lintOptionTexts(subject, option, locales)...)
+	}
+	return issues
+}
+
+func lintOptionTexts(parent string, o lintOption, locales []discord.Locale) []Issue {
+	subject := fmt.Sprintf("%s %s", parent, o.Name)
+	issues := lintName(subject, o.Name, o.NameLocalizations, locales, true)
+	issues = append(issues, lintText(subject+" description", o.Description, o.DescriptionLocalizations, locales, maxDescriptionLength)...)
+	for _, choice := range o.Choices {
+		issues = append(issues, lintText(fmt.Sprintf("%s choice '%s'", subject, choice.Name), choice.Name, choice.NameLocalizations, locales, maxChoiceNameLength)...)
+	}
+	for _, option := range o.Options {
+		issues = append(issues, lintOptionTexts(subject, option, locales)...)
+	}
+	return issues
+}
+
+// lintName checks a command or option name, slash names must be lower case and can't contain spaces
+func lintName(subject string, name string, localizations map[discord.Locale]string, locales []discord.Locale, slash bool) []Issue {
+	issues := lintText(subject+" name", name, localizations, locales, maxNameLength)
+	if !slash {
+		return issues
+	}
+	values := map[discord.Locale]string{DefaultLocale: name}
+	for locale, value := range localizations {
+		values[locale] = value
+	}
+	for _, locale := range sortedLocales(values) {
+		value := values[locale]
+		if !slashNameRegex.MatchString(value) || strings.ToLower(value) != value {
+			issues = append(issues, Issue{
+				Kind:    IssueLimit,
+				Subject: subject + " name",
+				Message: fmt.Sprintf("'%s' (%s) must be lower case and only contain letters, numbers, '-' and '_'", value, locale.Code()),
+			})
+		}
+	}
+	return issues
+}
+
+// lintText checks that a text is localized in every locale and fits in the length limit
+func lintText(subject string, text string, localizations map[discord.Locale]string, locales []discord.Locale, maxLength int) []Issue {
+	var issues []Issue
+	for _, locale := range locales {
+		if _, ok := localizations[locale]; !ok {
+			issues = append(issues, Issue{Kind: IssueMissingLocale, Subject: subject, Message: fmt.Sprintf("missing '%s' localization", locale.Code())})
+		}
+	}
+	values := map[discord.Locale]string{DefaultLocale: text}
+	for locale, value := range localizations {
+		values[locale] = value
+	}
+	for _, locale := range sortedLocales(values) {
+		length := utf8.RuneCountInString(values[locale])
+		if length == 0 || length > maxLength {
+			issues = append(issues, Issue{
+				Kind:    IssueLimit,
+				Subject: subject,
+				Message: fmt.Sprintf("'%s' (%s) must be between 1 and %d characters, got %d", values[locale], locale.Code(), maxLength, length),
+			})
+		}
+	}
+	return issues
+}
+
+// lintCatalogs checks that every message exists in every locale, with the same
+// placeholders, and that every message is used
+func lintCatalogs(locales []discord.Locale) []Issue {
+	var issues []Issue
+
+	keys := map[string]bool{}
+	for _, catalog := range catalogs {
+		for key := range catalog.Entries {
+			keys[key] = true
+		}
+	}
+	declared := DeclaredKeys()
+
+	sortedKeys := make([]string, 0, len(keys))
+	for key := range keys {
+		sortedKeys = append(sortedKeys, key)
+	}
+	sort.Strings(sortedKeys)
+
+	for _, key := range sortedKeys {
+		if _, found := slices.BinarySearch(declared, key); !found {
+			issues = append(issues, Issue{Kind: IssueUnusedKey, Subject: key, Message: "message is not used by any command or declared key"})
+		}
+
+		var reference []string
+		var referenceLocale discord.Locale
+		for _, locale := range locales {
+			entry, ok := Lookup(locale, key)
+			if !ok {
+				issues = append(issues, Issue{Kind: IssueMissingLocale, Subject: key, Message: fmt.Sprintf("missing in '%s' catalog", locale.Code())})
+				continue
+			}
+			placeholders := entryPlaceholders(entry)
+			if reference == nil {
+				reference, referenceLocale = placeholders, locale
+				continue
+			}
+			if !slices.Equal(reference, placeholders) {
+				issues = append(issues, Issue{
+					Kind:    IssuePlaceholderMismatch,
+					Subject: key,
+					Message: fmt.Sprintf("placeholders %v (%s) differ from %v (%s)", placeholders, locale.Code(), reference, referenceLocale.Code()),
+				})
+			}
+		}
+	}
+	return issues
+}
+
+// entryPlaceholders returns the placeholders of an entry, the count placeholder
+// being optional in plural forms
+func entryPlaceholders(entry Entry) []string {
+	set := map[string]bool{}
+	for _, text := range entry.Texts() {
+		for _, name := range Placeholders(text) {
+			set[name] = true
+		}
+	}
+	if entry.IsPlural() {
+		delete(set, CountVar)
+	}
+	names := make([]string, 0, len(set))
+	for name := range set {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func sortedLocales(values map[discord.Locale]string) []discord.Locale {
+	locales := make([]discord.Locale, 0, len(values))
+	for locale := range values {
+		locales = append(locales, locale)
+	}
+	slices.Sort(locales)
+	return locales
+}
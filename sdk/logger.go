@@ -70,15 +70,30 @@ func NewHandler(opts *slog.HandlerOptions) *LogHandler {
 	}
 }
 
+// logLevel is shared by the handlers, so that the level can be changed at runtime
+var logLevel = new(slog.LevelVar)
+
+// SetLogLevel changes the minimum level of the logs
+func SetLogLevel(level slog.Level) {
+	logLevel.Set(level)
+	slog.Info("Log level set", slog.String("level", level.String()))
+}
+
+// LogLevel returns the current minimum level of the logs
+func LogLevel() slog.Level {
+	return logLevel.Level()
+}
+
 func SetupLogger(cfg LogConfig) {
 
+	logLevel.Set(cfg.MinLevel())
 	var logger slog.Handler
 	switch cfg.Format {
 	case "text":
-		logger = NewHandler(nil)
+		logger = NewHandler(&slog.HandlerOptions{Level: logLevel})
 	case "json":
 		logger = NewHandler(&slog.HandlerOptions{
-			Level: slog.LevelDebug,
+			Level: logLevel,
 			ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
 				if a.Key == "nothing" {
 					return slog.Attr{}
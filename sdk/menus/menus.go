@@ -0,0 +1,93 @@
+package menus
+
+import (
+	"github.com/bil0u/galaxy-os/sdk/i18n"
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/handler"
+	"github.com/disgoorg/json"
+)
+
+// UserTarget is the user a user command was used on
+type UserTarget struct {
+	User discord.User
+	// Member is nil when the command was not used in a guild
+	Member *discord.ResolvedMember
+}
+
+// UserCommand is a context menu command shown on users.
+// Its name is read from the catalogs, under "<Key>.name"
+type UserCommand struct {
+	Key                      string
+	DefaultMemberPermissions *discord.Permissions
+	Handler                  func(e *handler.CommandEvent, target UserTarget) error
+}
+
+// Build returns the discord command matching the definition
+func (c UserCommand) Build() discord.UserCommandCreate {
+	nameKey := i18n.Declare(c.Key + ".name")
+	create := discord.UserCommandCreate{
+		Name:              nameKey.Default(),
+		NameLocalizations: nameKey.Localizations(),
+	}
+	if c.DefaultMemberPermissions != nil {
+		create.DefaultMemberPermissions = json.NewNullablePtr(*c.DefaultMemberPermissions)
+	}
+	return create
+}
+
+// Path returns the route of the command
+func (c UserCommand) Path() string {
+	return "/" + i18n.Key(c.Key+".name").Default()
+}
+
+// Handle resolves the target of the command and calls the command handler
+func (c UserCommand) Handle(e *handler.CommandEvent) error {
+	data := e.UserCommandInteractionData()
+	target := UserTarget{User: data.TargetUser()}
+	if member, ok := data.Resolved.Members[data.TargetID()]; ok {
+		member.User = target.User
+		target.Member = &member
+	}
+	return c.Handler(e, target)
+}
+
+// Register adds the route of the command to the router
+func (c UserCommand) Register(r handler.Router) {
+	r.Command(c.Path(), c.Handle)
+}
+
+// MessageCommand is a context menu command shown on messages.
+// Its name is read from the catalogs, under "<Key>.name"
+type MessageCommand struct {
+	Key                      string
+	DefaultMemberPermissions *discord.Permissions
+	Handler                  func(e *handler.CommandEvent, target discord.Message) error
+}
+
+// Build returns the discord command matching the definition
+func (c MessageCommand) Build() discord.MessageCommandCreate {
+	nameKey := i18n.Declare(c.Key + ".name")
+	create := discord.MessageCommandCreate{
+		Name:              nameKey.Default(),
+		NameLocalizations: nameKey.Localizations(),
+	}
+	if c.DefaultMemberPermissions != nil {
+		create.DefaultMemberPermissions = json.NewNullablePtr(*c.DefaultMemberPermissions)
+	}
+	return create
+}
+
+// Path returns the route of the command
+func (c MessageCommand) Path() string {
+	return "/" + i18n.Key(c.Key+".name").Default()
+}
+
+// Handle resolves the target message and calls the command handler
+func (c MessageCommand) Handle(e *handler.CommandEvent) error {
+	return c.Handler(e, e.MessageCommandInteractionData().TargetMessage())
+}
+
+// Register adds the route of the command to the router
+func (c MessageCommand) Register(r handler.Router) {
+	r.Command(c.Path(), c.Handle)
+}
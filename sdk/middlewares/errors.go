@@ -0,0 +1,107 @@
+package middlewares
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/bil0u/galaxy-os/sdk/i18n"
+	"github.com/disgoorg/disgo/discord"
+)
+
+// ErrorKind classifies an error returned by a handler, to pick the reply shown to the user
+type ErrorKind int
+
+const (
+	ErrorKindInternal ErrorKind = iota
+	ErrorKindValidation
+	ErrorKindForbidden
+	ErrorKindNotFound
+	ErrorKindRateLimited
+)
+
+var errorKindNames = map[ErrorKind]string{
+	ErrorKindInternal:    "internal",
+	ErrorKindValidation:  "validation",
+	ErrorKindForbidden:   "forbidden",
+	ErrorKindNotFound:    "not_found",
+	ErrorKindRateLimited: "rate_limited",
+}
+
+func (k ErrorKind) String() string {
+	if name, ok := errorKindNames[k]; ok {
+		return name
+	}
+	return fmt.Sprintf("unknown(%d)", int(k))
+}
+
+// Default messages shown to the user when an error does not carry its own message
+var errorKindMessages = map[ErrorKind]i18n.Key{
+	ErrorKindInternal:    i18n.Declare("errors.kinds.internal"),
+	ErrorKindValidation:  i18n.Declare("errors.kinds.validation"),
+	ErrorKindForbidden:   i18n.Declare("errors.kinds.forbidden"),
+	ErrorKindNotFound:    i18n.Declare("errors.kinds.not_found"),
+	ErrorKindRateLimited: i18n.Declare("errors.kinds.rate_limited"),
+}
+
+// UserError is an error that can be safely reported to the user who triggered the interaction
+type UserError struct {
+	Kind    ErrorKind
+	Message i18n.Message
+	Err     error
+}
+
+func (e *UserError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("%s error: %v", e.Kind, e.Err)
+	}
+	if !e.Message.IsZero() {
+		return fmt.Sprintf("%s error: %s", e.Kind, e.Message.T(i18n.DefaultLocale))
+	}
+	return fmt.Sprintf("%s error", e.Kind)
+}
+
+func (e *UserError) Unwrap() error {
+	return e.Err
+}
+
+// UserMessage returns the message to display to the user, in the given locale
+func (e *UserError) UserMessage(locale discord.Locale) string {
+	if !e.Message.IsZero() {
+		return e.Message.T(locale)
+	}
+	return errorKindMessages[e.Kind].T(locale)
+}
+
+// NewValidationError creates an error telling the user that their input is invalid
+func NewValidationError(message i18n.Message) error {
+	return &UserError{Kind: ErrorKindValidation, Message: message}
+}
+
+// NewForbiddenError creates an error telling the user that they are not allowed to do something
+func NewForbiddenError(message i18n.Message) error {
+	return &UserError{Kind: ErrorKindForbidden, Message: message}
+}
+
+// NewNotFoundError creates an error telling the user that something could not be found
+func NewNotFoundError(message i18n.Message) error {
+	return &UserError{Kind: ErrorKindNotFound, Message: message}
+}
+
+// NewRateLimitedError creates an error telling the user to slow down
+func NewRateLimitedError(message i18n.Message) error {
+	return &UserError{Kind: ErrorKindRateLimited, Message: message}
+}
+
+// NewInternalError wraps an unexpected error, the user only gets a generic message
+func NewInternalError(err error) error {
+	return &UserError{Kind: ErrorKindInternal, Err: err}
+}
+
+// AsUserError maps any error to a UserError, defaulting to an internal error
+func AsUserError(err error) *UserError {
+	var userErr *UserError
+	if errors.As(err, &userErr) {
+		return userErr
+	}
+	return &UserError{Kind: ErrorKindInternal, Err: err}
+}
@@ -0,0 +1,123 @@
+package middlewares
+
+import (
+	"fmt"
+	"log/slog"
+	"runtime/debug"
+
+	"github.com/bil0u/galaxy-os/sdk/i18n"
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/handler"
+	"github.com/disgoorg/disgo/rest"
+)
+
+var errorReferenceLabel = i18n.Declare("errors.reference")
+
+// Recover is a middleware that recovers from panics in handlers and replies to the user
+// with a localized ephemeral message when a handler panics or returns an error
+var Recover handler.Middleware = func(next handler.Handler) handler.Handler {
+	return func(e *handler.InteractionEvent) (err error) {
+		// Keep track of the response sent by the handler, if any
+		var responseType discord.InteractionResponseType
+		respond := e.Respond
+		e.Respond = func(t discord.InteractionResponseType, data discord.InteractionResponseData, opts ...rest.RequestOpt) error {
+			if err := respond(t, data, opts...); err != nil {
+				return err
+			}
+			responseType = t
+			return nil
+		}
+
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("panic: %v", r)
+				slog.Error("Recovered from panic in interaction handler",
+					slog.String("reference", ErrorReference(e)),
+					slog.Any("panic", r),
+					slog.String("stack", string(debug.Stack())),
+				)
+			}
+			if err != nil {
+				userErr := AsUserError(err)
+				if userErr.Kind == ErrorKindInternal {
+					slog.Error("Failed to handle interaction",
+						slog.String("reference", ErrorReference(e)),
+						slog.Any("err", err),
+					)
+				} else {
+					slog.Debug("Interaction handler returned a user error",
+						slog.String("reference", ErrorReference(e)),
+						slog.String("kind", userErr.Kind.String()),
+						slog.Any("err", err),
+					)
+				}
+				if replyErr := replyWithError(e, respond, responseType, userErr); replyErr != nil {
+					slog.Error("Failed to reply with error", slog.String("reference", ErrorReference(e)), slog.Any("err", replyErr))
+				}
+				// The error has been handled, don't let the router log it again
+				err = nil
+			}
+		}()
+
+		return next(e)
+	}
+}
+
+// ErrorReference returns the reference given to the user to identify an error in the logs
+func ErrorReference(e *handler.InteractionEvent) string {
+	return e.ID().String()
+}
+
+func replyWithError(e *handler.InteractionEvent, respond func(discord.InteractionResponseType, discord.InteractionResponseData, ...rest.RequestOpt) error, responseType discord.InteractionResponseType, userErr *UserError) error {
+	content := fmt.Sprintf("%s\n-# %s: `%s`", userErr.UserMessage(e.Locale()), errorReferenceLabel.T(e.Locale()), ErrorReference(e))
+
+	// Autocomplete interactions can't display messages, answer with no choices
+	if e.Type() == discord.InteractionTypeAutocomplete {
+		if responseType != 0 {
+			return nil
+		}
+		return respond(discord.InteractionResponseTypeAutocompleteResult, discord.AutocompleteResult{Choices: []discord.AutocompleteChoice{}})
+	}
+
+	switch responseType {
+	case 0:
+		// Nothing sent yet, reply directly
+		return respond(discord.InteractionResponseTypeCreateMessage, discord.MessageCreate{
+			Content: content,
+			Flags:   discord.MessageFlagEphemeral,
+		})
+	case discord.InteractionResponseTypeDeferredCreateMessage:
+		// Edit the "bot is thinking..." message
+		_, err := e.UpdateInteractionResponse(discord.MessageUpdate{
+			Content:    &content,
+			Embeds:     &[]discord.Embed{},
+			Components: &[]discord.ContainerComponent{},
+		})
+		return err
+	default:
+		// A response has already been sent, add a followup message
+		_, err := e.CreateFollowupMessage(discord.MessageCreate{
+			Content: content,
+			Flags:   discord.MessageFlagEphemeral,
+		})
+		return err
+	}
+}
+
+// InteractionPath returns the path used by the router to match the interaction
+func InteractionPath(e *handler.InteractionEvent) string {
+	switch i := e.Interaction.(type) {
+	case discord.ApplicationCommandInteraction:
+		if data, ok := i.Data.(discord.SlashCommandInteractionData); ok {
+			return data.CommandPath()
+		}
+		return "/" + i.Data.CommandName()
+	case discord.AutocompleteInteraction:
+		return i.Data.CommandPath()
+	case discord.ComponentInteraction:
+		return i.Data.CustomID()
+	case discord.ModalSubmitInteraction:
+		return i.Data.CustomID
+	}
+	return ""
+}
@@ -0,0 +1,317 @@
+package modals
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/bil0u/galaxy-os/sdk/i18n"
+	"github.com/bil0u/galaxy-os/sdk/middlewares"
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/handler"
+	"github.com/disgoorg/disgo/rest"
+	"github.com/disgoorg/snowflake/v2"
+)
+
+// Struct tags understood when building a modal from a struct, fields must be strings:
+//
+//	name      custom ID of the text input, fields without it are ignored
+//	style     "short" (default) or "paragraph"
+//	required  "true" if the input must be filled
+//	min, max  min/max length of the input
+//
+// Labels and placeholders are read from the catalogs, under "<modal key>.fields.<name>.label"
+// and "<modal key>.fields.<name>.placeholder" (optional).
+const (
+	tagName     = "name"
+	tagStyle    = "style"
+	tagRequired = "required"
+	tagMin      = "min"
+	tagMax      = "max"
+)
+
+// Discord allows at most 5 text inputs in a modal
+const maxInputs = 5
+
+var (
+	invalidSubmissionMessage = i18n.Declare("modals.invalid")
+	retryLabel               = i18n.Declare("modals.retry")
+)
+
+// Validator can be implemented by modal structs to check the submitted values
+type Validator interface {
+	Validate() error
+}
+
+// Opener is an event that can be answered with a modal, e.g. a command or a button click
+type Opener interface {
+	Modal(modalCreate discord.ModalCreate, opts ...rest.RequestOpt) error
+	Locale() discord.Locale
+}
+
+type inputSpec struct {
+	Field     int
+	Name      string
+	Style     discord.TextInputStyle
+	Required  bool
+	MinLength *int
+	MaxLength int
+}
+
+// Modal is a form whose text inputs are declared by the tagged fields of T.
+// The title is read from the catalogs, under "<Key>.title"
+type Modal[T any] struct {
+	Key string
+	// CustomID is the route of the modal submission, e.g. "/report"
+	CustomID string
+	Handler  func(e *handler.ModalEvent, data T) error
+}
+
+// draftTTL is how long an invalid submission is kept to pre-fill the modal when retrying
+const draftTTL = 15 * time.Minute
+
+type draft struct {
+	data      any
+	expiresAt time.Time
+}
+
+// drafts holds the last invalid submission of each user, keyed by modal and user
+var (
+	drafts   = map[string]draft{}
+	draftsMu sync.Mutex
+)
+
+func draftKey(customID string, userID snowflake.ID) string {
+	return fmt.Sprintf("%s:%s", customID, userID)
+}
+
+func saveDraft(key string, data any) {
+	draftsMu.Lock()
+	defer draftsMu.Unlock()
+	now := time.Now()
+	for k, d := range drafts {
+		if now.After(d.expiresAt) {
+			delete(drafts, k)
+		}
+	}
+	drafts[key] = draft{data: data, expiresAt: now.Add(draftTTL)}
+}
+
+func loadDraft(key string) (any, bool) {
+	draftsMu.Lock()
+	defer draftsMu.Unlock()
+	d, ok := drafts[key]
+	if !ok || time.Now().After(d.expiresAt) {
+		return nil, false
+	}
+	return d.data, true
+}
+
+func deleteDraft(key string) {
+	draftsMu.Lock()
+	defer draftsMu.Unlock()
+	delete(drafts, key)
+}
+
+func (m Modal[T]) specs() ([]inputSpec, error) {
+	t := reflect.TypeFor[T]()
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("modal '%s' must be a struct, got %s", m.Key, t.Kind())
+	}
+
+	var specs []inputSpec
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, ok := field.Tag.Lookup(tagName)
+		if !ok || !field.IsExported() {
+			continue
+		}
+		if field.Type.Kind() != reflect.String {
+			return nil, fmt.Errorf("modal '%s' field '%s' must be a string", m.Key, field.Name)
+		}
+		spec := inputSpec{
+			Field:    i,
+			Name:     name,
+			Style:    discord.TextInputStyleShort,
+			Required: field.Tag.Get(tagRequired) == "true",
+		}
+		switch style := field.Tag.Get(tagStyle); style {
+		case "", "short":
+		case "paragraph":
+			spec.Style = discord.TextInputStyleParagraph
+		default:
+			return nil, fmt.Errorf("modal '%s' field '%s' has unknown style '%s'", m.Key, field.Name, style)
+		}
+		if raw, ok := field.Tag.Lookup(tagMin); ok {
+			value, err := strconv.Atoi(raw)
+			if err != nil {
+				return nil, fmt.Errorf("modal '%s' field '%s' has invalid min: %w", m.Key, field.Name, err)
+			}
+			spec.MinLength = &value
+		}
+		if raw, ok := field.Tag.Lookup(tagMax); ok {
+			value, err := strconv.Atoi(raw)
+			if err != nil {
+				return nil, fmt.Errorf("modal '%s' field '%s' has invalid max: %w", m.Key, field.Name, err)
+			}
+			spec.MaxLength = value
+		}
+		specs = append(specs, spec)
+	}
+	if len(specs) == 0 || len(specs) > maxInputs {
+		return nil, fmt.Errorf("modal '%s' must have between 1 and %d inputs, got %d", m.Key, maxInputs, len(specs))
+	}
+	return specs, nil
+}
+
+// Verify checks the definition of the modal and declares its catalog keys
+func (m Modal[T]) Verify() error {
+	specs, err := m.specs()
+	if err != nil {
+		return err
+	}
+	if _, ok := i18n.Lookup(i18n.DefaultLocale, m.Key+".title"); !ok {
+		return fmt.Errorf("modal '%s' has no title in the default catalog", m.Key)
+	}
+	i18n.Declare(m.Key + ".title")
+	for _, spec := range specs {
+		label := fmt.Sprintf("%s.fields.%s.label", m.Key, spec.Name)
+		if _, ok := i18n.Lookup(i18n.DefaultLocale, label); !ok {
+			return fmt.Errorf("modal '%s' field '%s' has no label in the default catalog", m.Key, spec.Name)
+		}
+		i18n.Declare(label)
+		placeholder := fmt.Sprintf("%s.fields.%s.placeholder", m.Key, spec.Name)
+		if _, ok := i18n.Lookup(i18n.DefaultLocale, placeholder); ok {
+			i18n.Declare(placeholder)
+		}
+	}
+	return nil
+}
+
+// Must verifies the modal and returns it, it panics if the modal is invalid to fail at startup
+func Must[T any](m Modal[T]) Modal[T] {
+	if err := m.Verify(); err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// Build returns the modal in the given locale, the inputs are pre-filled with the values of data
+func (m Modal[T]) Build(locale discord.Locale, data T) (discord.ModalCreate, error) {
+	specs, err := m.specs()
+	if err != nil {
+		return discord.ModalCreate{}, err
+	}
+	values := reflect.ValueOf(data)
+
+	modal := discord.ModalCreate{
+		CustomID: m.CustomID,
+		Title:    i18n.T(locale, m.Key+".title"),
+	}
+	for _, spec := range specs {
+		input := discord.TextInputComponent{
+			CustomID:  spec.Name,
+			Style:     spec.Style,
+			Label:     i18n.T(locale, fmt.Sprintf("%s.fields.%s.label", m.Key, spec.Name)),
+			MinLength: spec.MinLength,
+			MaxLength: spec.MaxLength,
+			Required:  spec.Required,
+			Value:     values.Field(spec.Field).String(),
+		}
+		placeholder := fmt.Sprintf("%s.fields.%s.placeholder", m.Key, spec.Name)
+		if _, ok := i18n.Lookup(i18n.DefaultLocale, placeholder); ok {
+			input.Placeholder = i18n.T(locale, placeholder)
+		}
+		modal.Components = append(modal.Components, discord.NewActionRow(input))
+	}
+	return modal, nil
+}
+
+// Open answers the event with the modal, in the locale of the user
+func (m Modal[T]) Open(e Opener) error {
+	var empty T
+	return m.OpenWith(e, empty)
+}
+
+// OpenWith answers the event with the modal, pre-filled with the values of data
+func (m Modal[T]) OpenWith(e Opener, data T) error {
+	modal, err := m.Build(e.Locale(), data)
+	if err != nil {
+		return err
+	}
+	return e.Modal(modal)
+}
+
+// Handle decodes the submitted values into T, validates them and calls the modal handler.
+// Invalid submissions are answered with the error and a button to fix the values
+func (m Modal[T]) Handle(e *handler.ModalEvent) error {
+	specs, err := m.specs()
+	if err != nil {
+		return err
+	}
+
+	var data T
+	values := reflect.ValueOf(&data).Elem()
+	for _, spec := range specs {
+		values.Field(spec.Field).SetString(e.Data.Text(spec.Name))
+	}
+
+	if validator, ok := any(&data).(Validator); ok {
+		if err := validator.Validate(); err != nil {
+			return m.replyInvalid(e, data, err)
+		}
+	}
+
+	deleteDraft(draftKey(m.CustomID, e.User().ID))
+	return m.Handler(e, data)
+}
+
+func (m Modal[T]) replyInvalid(e *handler.ModalEvent, data T, err error) error {
+	saveDraft(draftKey(m.CustomID, e.User().ID), data)
+
+	message := invalidSubmissionMessage.T(e.Locale())
+	var userErr *middlewares.UserError
+	if errors.As(err, &userErr) {
+		message = userErr.UserMessage(e.Locale())
+	}
+	return e.CreateMessage(discord.NewMessageCreateBuilder().
+		SetContent(message).
+		SetEphemeral(true).
+		AddActionRow(discord.NewSecondaryButton(retryLabel.T(e.Locale()), m.retryCustomID())).
+		Build(),
+	)
+}
+
+func (m Modal[T]) retryCustomID() string {
+	return m.CustomID + "/retry"
+}
+
+// retry opens the modal again, pre-filled with the last invalid submission of the user
+func (m Modal[T]) retry(e *handler.ComponentEvent) error {
+	stored, ok := loadDraft(draftKey(m.CustomID, e.User().ID))
+	data, isT := stored.(T)
+	if !ok || !isT {
+		return m.Open(e)
+	}
+	return m.OpenWith(e, data)
+}
+
+// Register verifies the modal then adds the routes of its submission and retry button to the router
+func (m Modal[T]) Register(r handler.Router) error {
+	if err := m.Verify(); err != nil {
+		return err
+	}
+	r.Modal(m.CustomID, m.Handle)
+	r.Component(m.retryCustomID(), m.retry)
+	return nil
+}
+
+// MustRegister is like Register but panics if the modal is invalid, to fail at startup
+func (m Modal[T]) MustRegister(r handler.Router) {
+	if err := m.Register(r); err != nil {
+		panic(err)
+	}
+}
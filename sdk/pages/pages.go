@@ -0,0 +1,368 @@
+package pages
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bil0u/galaxy-os/sdk/i18n"
+	"github.com/bil0u/galaxy-os/sdk/middlewares"
+	"github.com/disgoorg/disgo/bot"
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/events"
+	"github.com/disgoorg/disgo/handler"
+	"github.com/disgoorg/disgo/rest"
+	"github.com/disgoorg/paginator"
+	"github.com/disgoorg/snowflake/v2"
+)
+
+// DefaultTimeout is how long a list can be browsed after its last use
+const DefaultTimeout = 10 * time.Minute
+
+// maxDescriptionLength is the maximum length of an embed description allowed by discord
+const maxDescriptionLength = 4096
+
+const (
+	routePrefix = "/pages"
+	pageInput   = "page"
+	// customIDPrefix is the prefix of the custom IDs of the paginator buttons, "<prefix>:<list ID>:<action>"
+	customIDPrefix = "paginator"
+)
+
+var (
+	emptyMessage    = i18n.Declare("pages.empty")
+	notOwnerMessage = i18n.Declare("pages.not_owner")
+	expiredMessage  = i18n.Declare("pages.expired")
+	jumpLabel       = i18n.Declare("pages.jump.label")
+	jumpTitle       = i18n.Declare("pages.jump.title")
+	jumpInputLabel  = i18n.Declare("pages.jump.input")
+	invalidPage     = i18n.Declare("pages.jump.invalid")
+)
+
+// ManagerOptions configure the paginator manager browsing the lists
+func ManagerOptions() []paginator.ConfigOpt {
+	return []paginator.ConfigOpt{
+		paginator.WithCustomIDPrefix(customIDPrefix),
+		paginator.WithTimeout(DefaultTimeout),
+		paginator.WithNoPermissionMessage(notOwnerMessage.Default()),
+	}
+}
+
+// Responder is an event that can be answered with a list, e.g. a command or a button click
+type Responder interface {
+	CreateMessage(messageCreate discord.MessageCreate, opts ...rest.RequestOpt) error
+	ID() snowflake.ID
+	User() discord.User
+	Locale() discord.Locale
+}
+
+// List is a paginated embed showing a slice of items
+type List[T any] struct {
+	// Title of the embed, already translated
+	Title string
+	Items []T
+	// PerPage defaults to 10 items
+	PerPage int
+	// Render returns the line of an item in the embed
+	Render func(locale discord.Locale, item T) string
+	// Jump adds a button opening a modal to go to a given page
+	Jump      bool
+	Ephemeral bool
+	// Color of the embed, defaults to no color
+	Color int
+}
+
+// list is a list being browsed, the page shown is kept here as the manager doesn't expose it
+type list struct {
+	mu       sync.Mutex
+	owner    snowflake.ID
+	pages    int
+	current  int
+	jump     bool
+	locale   discord.Locale
+	render   func(locale discord.Locale, page int, embed *discord.EmbedBuilder)
+	lastUsed time.Time
+}
+
+var (
+	listsMu sync.Mutex
+	lists   = map[string]*list{}
+)
+
+// track keeps the list until it expires, the lists that already expired are forgotten
+func track(id string, l *list) {
+	listsMu.Lock()
+	defer listsMu.Unlock()
+	now := time.Now()
+	for otherID, other := range lists {
+		other.mu.Lock()
+		if now.Sub(other.lastUsed) > DefaultTimeout {
+			delete(lists, otherID)
+		}
+		other.mu.Unlock()
+	}
+	lists[id] = l
+}
+
+func lookup(id string) *list {
+	listsMu.Lock()
+	defer listsMu.Unlock()
+	return lists[id]
+}
+
+// Respond answers the event with the first page of the list, only the user of the event can browse it
+func Respond[T any](m *paginator.Manager, e Responder, items List[T]) error {
+	perPage := items.PerPage
+	if perPage <= 0 {
+		perPage = 10
+	}
+	pages := max((len(items.Items)+perPage-1)/perPage, 1)
+
+	l := &list{
+		owner:    e.User().ID,
+		pages:    pages,
+		jump:     items.Jump,
+		locale:   e.Locale(),
+		lastUsed: time.Now(),
+		render: func(locale discord.Locale, page int, embed *discord.EmbedBuilder) {
+			renderPage(items, locale, page, perPage, embed)
+		},
+	}
+
+	// Lists of a single page have nothing to browse
+	if pages == 1 {
+		embed := discord.NewEmbedBuilder()
+		l.render(l.locale, 0, embed)
+		message := discord.MessageCreate{Embeds: []discord.Embed{embed.Build()}}
+		if items.Ephemeral {
+			message.Flags = discord.MessageFlagEphemeral
+		}
+		return e.CreateMessage(message)
+	}
+
+	// The interaction that created the list identifies it
+	id := strconv.FormatUint(uint64(e.ID()), 36)
+	track(id, l)
+	respond := func(_ discord.InteractionResponseType, data discord.InteractionResponseData, opts ...rest.RequestOpt) error {
+		message := data.(discord.MessageCreate)
+		if l.jump {
+			message.Components = append(message.Components, jumpRow(id, l.locale))
+		}
+		return e.CreateMessage(message, opts...)
+	}
+	return m.Create(respond, l.paginator(id), items.Ephemeral)
+}
+
+// paginator returns the pages browsed by the manager, from the first page
+func (l *list) paginator(id string) paginator.Pages {
+	return paginator.Pages{
+		ID:         id,
+		Pages:      l.pages,
+		Creator:    l.owner,
+		ExpireMode: paginator.ExpireModeAfterLastUsage,
+		PageFunc: func(page int, embed *discord.EmbedBuilder) {
+			l.mu.Lock()
+			defer l.mu.Unlock()
+			l.current = page
+			l.lastUsed = time.Now()
+			l.render(l.locale, page, embed)
+		},
+	}
+}
+
+func renderPage[T any](items List[T], locale discord.Locale, page int, perPage int, embed *discord.EmbedBuilder) {
+	start := page * perPage
+	end := min(start+perPage, len(items.Items))
+
+	lines := make([]string, 0, end-start)
+	for _, item := range items.Items[start:end] {
+		lines = append(lines, items.Render(locale, item))
+	}
+	description := strings.Join(lines, "\n")
+	if len(items.Items) == 0 {
+		description = emptyMessage.T(locale)
+	}
+	if runes := []rune(description); len(runes) > maxDescriptionLength {
+		description = string(runes[:maxDescriptionLength-1]) + "…"
+	}
+
+	embed.SetTitle(items.Title).
+		SetDescription(description).
+		SetColor(items.Color)
+}
+
+func jumpCustomID(id string) string {
+	return fmt.Sprintf("%s/%s/jump", routePrefix, id)
+}
+
+// jumpRow returns the row of the jump button, below the buttons of the paginator
+func jumpRow(id string, locale discord.Locale) discord.ContainerComponent {
+	return discord.NewActionRow(discord.NewSecondaryButton(jumpLabel.T(locale), jumpCustomID(id)))
+}
+
+// withJump adds the jump button to a paginator message update, if the list has one
+func withJump(id string, update discord.MessageUpdate) discord.MessageUpdate {
+	l := lookup(id)
+	// Expired lists are updated without components, they must not get the button back
+	if l == nil || !l.jump || update.Components == nil || len(*update.Components) == 0 {
+		return update
+	}
+	l.mu.Lock()
+	locale := l.locale
+	l.mu.Unlock()
+	components := append(slices.Clone(*update.Components), jumpRow(id, locale))
+	update.Components = &components
+	return update
+}
+
+// withJumpResponder returns a responder adding the jump button to the message updates of the list
+func withJumpResponder(id string, respond events.InteractionResponderFunc) events.InteractionResponderFunc {
+	return func(responseType discord.InteractionResponseType, data discord.InteractionResponseData, opts ...rest.RequestOpt) error {
+		if update, ok := data.(discord.MessageUpdate); ok {
+			data = withJump(id, update)
+		}
+		return respond(responseType, data, opts...)
+	}
+}
+
+// Listener returns the listener of the manager, which handles the paginator buttons.
+// It is used instead of the manager itself, so that the jump button stays below the paginator buttons
+func Listener(m *paginator.Manager) bot.EventListener {
+	return bot.NewListenerFunc(func(e *events.ComponentInteractionCreate) {
+		parts := strings.Split(e.Data.CustomID(), ":")
+		if len(parts) != 3 || parts[0] != customIDPrefix {
+			return
+		}
+		m.OnEvent(&events.ComponentInteractionCreate{
+			GenericEvent:         e.GenericEvent,
+			ComponentInteraction: e.ComponentInteraction,
+			Respond:              withJumpResponder(parts[1], e.Respond),
+		})
+	})
+}
+
+// Register adds the routes of the jump button and of the jump modal to the router
+func Register(r handler.Router, m *paginator.Manager) {
+	r.Component(routePrefix+"/{id}/jump", handleJumpButton)
+	r.Modal(routePrefix+"/{id}/jump", func(e *handler.ModalEvent) error {
+		return handleJump(m, e)
+	})
+}
+
+// get returns the list of the given ID, checking that the user can browse it
+func get(id string, userID snowflake.ID) (*list, error) {
+	l := lookup(id)
+	if l == nil {
+		return nil, middlewares.NewNotFoundError(expiredMessage.With(nil))
+	}
+	if l.owner != userID {
+		return nil, middlewares.NewForbiddenError(notOwnerMessage.With(nil))
+	}
+	return l, nil
+}
+
+func handleJumpButton(e *handler.ComponentEvent) error {
+	id := e.Vars["id"]
+	l, err := get(id, e.User().ID)
+	if err != nil {
+		if middlewares.AsUserError(err).Kind == middlewares.ErrorKindNotFound {
+			// Remove the buttons of the expired list, the error is then sent as a followup
+			_ = e.UpdateMessage(discord.MessageUpdate{Components: &[]discord.ContainerComponent{}})
+		}
+		return err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.lastUsed = time.Now()
+	return e.Modal(discord.ModalCreate{
+		CustomID: jumpCustomID(id),
+		Title:    jumpTitle.T(e.Locale()),
+		Components: []discord.ContainerComponent{
+			discord.NewActionRow(discord.TextInputComponent{
+				CustomID:    pageInput,
+				Style:       discord.TextInputStyleShort,
+				Label:       jumpInputLabel.T(e.Locale(), i18n.Vars{"pages": l.pages}),
+				Required:    true,
+				MaxLength:   len(strconv.Itoa(l.pages)),
+				Placeholder: strconv.Itoa(l.current + 1),
+			}),
+		},
+	})
+}
+
+// handleJump shows the page entered in the modal, the other pages can still be browsed from there
+func handleJump(m *paginator.Manager, e *handler.ModalEvent) error {
+	id := e.Vars["id"]
+	l, err := get(id, e.User().ID)
+	if err != nil {
+		return err
+	}
+
+	page, err := strconv.Atoi(strings.TrimSpace(e.Data.Text(pageInput)))
+	if err != nil || page < 1 || page > l.pages {
+		return middlewares.NewValidationError(invalidPage.With(i18n.Vars{"pages": l.pages}))
+	}
+	l.mu.Lock()
+	l.locale = e.Locale()
+	l.mu.Unlock()
+	return seek(m, e.GenericEvent, e.User(), id, l, page-1, withJumpResponder(id, e.Respond))
+}
+
+// discardResponse is a responder sending nothing
+func discardResponse(discord.InteractionResponseType, discord.InteractionResponseData, ...rest.RequestOpt) error {
+	return nil
+}
+
+// seek responds with the given page of a list. The manager can't be told which page to show, so the list
+// is created again on its first page, then moved to the page with the next button, as if the user clicked it
+func seek(m *paginator.Manager, generic *events.GenericEvent, user discord.User, id string, l *list, page int, respond events.InteractionResponderFunc) error {
+	if page == 0 {
+		return m.Update(respond, l.paginator(id))
+	}
+	if err := m.Update(discardResponse, l.paginator(id)); err != nil {
+		return err
+	}
+	next, err := buttonInteraction(user, customIDPrefix+":"+id+":next")
+	if err != nil {
+		return err
+	}
+
+	// Only the update of the last click is sent
+	var update discord.InteractionResponseData
+	for range page {
+		m.OnEvent(&events.ComponentInteractionCreate{
+			GenericEvent:         generic,
+			ComponentInteraction: next,
+			Respond: func(_ discord.InteractionResponseType, data discord.InteractionResponseData, _ ...rest.RequestOpt) error {
+				update = data
+				return nil
+			},
+		})
+	}
+	if update == nil {
+		return middlewares.NewNotFoundError(expiredMessage.With(nil))
+	}
+	return respond(discord.InteractionResponseTypeUpdateMessage, update)
+}
+
+// buttonInteraction returns a click of the user on a button, the interaction can only be handled locally
+func buttonInteraction(user discord.User, customID string) (discord.ComponentInteraction, error) {
+	data, err := json.Marshal(map[string]any{
+		"type": discord.InteractionTypeComponent,
+		"user": user,
+		"data": map[string]any{"component_type": discord.ComponentTypeButton, "custom_id": customID},
+	})
+	if err != nil {
+		return discord.ComponentInteraction{}, err
+	}
+	var interaction discord.ComponentInteraction
+	if err := json.Unmarshal(data, &interaction); err != nil {
+		return discord.ComponentInteraction{}, err
+	}
+	return interaction, nil
+}
@@ -0,0 +1,105 @@
+package pages
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/events"
+	"github.com/disgoorg/disgo/rest"
+	"github.com/disgoorg/paginator"
+	"github.com/disgoorg/snowflake/v2"
+)
+
+// testResponder is a command answered with a list
+type testResponder struct {
+	id      snowflake.ID
+	user    discord.User
+	message discord.MessageCreate
+}
+
+func (r *testResponder) CreateMessage(message discord.MessageCreate, _ ...rest.RequestOpt) error {
+	r.message = message
+	return nil
+}
+func (r *testResponder) ID() snowflake.ID       { return r.id }
+func (r *testResponder) User() discord.User     { return r.user }
+func (r *testResponder) Locale() discord.Locale { return discord.LocaleEnglishUS }
+
+// captureUpdate returns a responder keeping the message update it is given
+func captureUpdate(update *discord.MessageUpdate) events.InteractionResponderFunc {
+	return func(_ discord.InteractionResponseType, data discord.InteractionResponseData, _ ...rest.RequestOpt) error {
+		*update = data.(discord.MessageUpdate)
+		return nil
+	}
+}
+
+// buttons returns the disabled state of the paginator buttons, by action
+func buttons(t *testing.T, components []discord.ContainerComponent) map[string]bool {
+	t.Helper()
+	disabled := map[string]bool{}
+	for _, component := range components[0].(discord.ActionRowComponent).Components() {
+		button := component.(discord.ButtonComponent)
+		disabled[button.CustomID[strings.LastIndex(button.CustomID, ":")+1:]] = button.Disabled
+	}
+	return disabled
+}
+
+func TestJump(t *testing.T) {
+	m := paginator.New(ManagerOptions()...)
+	user := discord.User{ID: 42}
+	e := &testResponder{id: 1000, user: user}
+
+	items := make([]int, 45)
+	for i := range items {
+		items[i] = i + 1
+	}
+	err := Respond(m, e, List[int]{
+		Title:  "Numbers",
+		Items:  items,
+		Jump:   true,
+		Render: func(_ discord.Locale, item int) string { return strconv.Itoa(item) },
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if footer := e.message.Embeds[0].Footer.Text; footer != "Page: 1/5" {
+		t.Errorf("footer of the first page = %q, want 'Page: 1/5'", footer)
+	}
+	if len(e.message.Components) != 2 {
+		t.Fatalf("first page has %d component rows, want the paginator and the jump rows", len(e.message.Components))
+	}
+
+	id := strconv.FormatUint(uint64(e.ID()), 36)
+	l := lookup(id)
+	var update discord.MessageUpdate
+	if err := seek(m, nil, user, id, l, 2, withJumpResponder(id, captureUpdate(&update))); err != nil {
+		t.Fatal(err)
+	}
+	embed := (*update.Embeds)[0]
+	if embed.Footer.Text != "Page: 3/5" || embed.Description[:2] != "21" {
+		t.Errorf("jumped to footer %q, description %q, want page 3 starting at 21", embed.Footer.Text, embed.Description)
+	}
+	disabled := buttons(t, *update.Components)
+	if disabled["first"] || disabled["back"] || disabled["next"] || disabled["last"] {
+		t.Errorf("buttons disabled after the jump: %v, want all of them enabled", disabled)
+	}
+	if len(*update.Components) != 2 {
+		t.Errorf("jumped page has %d component rows, want the paginator and the jump rows", len(*update.Components))
+	}
+
+	// The pages before the one jumped to can still be browsed
+	back, err := buttonInteraction(user, fmt.Sprintf("%s:%s:back", customIDPrefix, id))
+	if err != nil {
+		t.Fatal(err)
+	}
+	m.OnEvent(&events.ComponentInteractionCreate{ComponentInteraction: back, Respond: captureUpdate(&update)})
+	if footer := (*update.Embeds)[0].Footer.Text; footer != "Page: 2/5" {
+		t.Errorf("footer after going back = %q, want 'Page: 2/5'", footer)
+	}
+	if l.current != 1 {
+		t.Errorf("current page = %d, want 1", l.current)
+	}
+}
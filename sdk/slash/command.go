@@ -0,0 +1,92 @@
+package slash
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/bil0u/galaxy-os/sdk/i18n"
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/handler"
+	"github.com/disgoorg/json"
+)
+
+// Command is a slash command whose options are declared by the tagged fields of T,
+// see the tag constants in options.go for the supported tags.
+// The name and description of the command are read from the catalogs, under "<Key>.name" and "<Key>.description"
+type Command[T any] struct {
+	Key                      string
+	DefaultMemberPermissions *discord.Permissions
+	Handler                  func(e *handler.CommandEvent, options T) error
+}
+
+// specsCacheKey identifies the option specs of a command, as the same options struct can be used by several commands
+type specsCacheKey struct {
+	Type reflect.Type
+	Key  string
+}
+
+// specsCache holds the option specs of each command, so that options structs are only read once
+var specsCache sync.Map
+
+func (c Command[T]) specs() ([]optionSpec, error) {
+	cacheKey := specsCacheKey{Type: reflect.TypeFor[T](), Key: c.Key}
+	if specs, ok := specsCache.Load(cacheKey); ok {
+		return specs.([]optionSpec), nil
+	}
+	specs, err := parseOptions(cacheKey.Type, c.Key+".options")
+	if err != nil {
+		return nil, fmt.Errorf("invalid options for command '%s': %w", c.Key, err)
+	}
+	specsCache.Store(cacheKey, specs)
+	return specs, nil
+}
+
+// Build returns the discord command matching the definition
+func (c Command[T]) Build() (discord.SlashCommandCreate, error) {
+	specs, err := c.specs()
+	if err != nil {
+		return discord.SlashCommandCreate{}, err
+	}
+
+	nameKey := i18n.Declare(c.Key + ".name")
+	descriptionKey := i18n.Declare(c.Key + ".description")
+	create := discord.SlashCommandCreate{
+		Name:                     nameKey.Default(),
+		NameLocalizations:        nameKey.Localizations(),
+		Description:              descriptionKey.Default(),
+		DescriptionLocalizations: descriptionKey.Localizations(),
+	}
+	if c.DefaultMemberPermissions != nil {
+		create.DefaultMemberPermissions = json.NewNullablePtr(*c.DefaultMemberPermissions)
+	}
+	for _, spec := range specs {
+		create.Options = append(create.Options, spec.create())
+	}
+	return create, nil
+}
+
+// MustBuild is like Build but panics if the definition is invalid,
+// which makes invalid tags fail at startup rather than when syncing commands
+func (c Command[T]) MustBuild() discord.SlashCommandCreate {
+	create, err := c.Build()
+	if err != nil {
+		panic(err)
+	}
+	return create
+}
+
+// Handle decodes the interaction options into T and calls the command handler.
+// Decoding errors are returned as validation errors, so they are reported to the user
+func (c Command[T]) Handle(e *handler.CommandEvent) error {
+	specs, err := c.specs()
+	if err != nil {
+		return err
+	}
+
+	var options T
+	if err := decodeOptions(e.SlashCommandInteractionData(), specs, reflect.ValueOf(&options).Elem()); err != nil {
+		return err
+	}
+	return c.Handler(e, options)
+}
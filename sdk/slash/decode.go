@@ -0,0 +1,123 @@
+package slash
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+
+	"github.com/bil0u/galaxy-os/sdk/i18n"
+	"github.com/bil0u/galaxy-os/sdk/middlewares"
+	"github.com/disgoorg/disgo/discord"
+)
+
+// Validator can be implemented by options structs to add checks run after decoding
+type Validator interface {
+	Validate() error
+}
+
+var (
+	missingOptionMessage = i18n.Declare("errors.options.missing")
+	invalidChoiceMessage = i18n.Declare("errors.options.invalid_choice")
+	outOfRangeMessage    = i18n.Declare("errors.options.out_of_range")
+)
+
+// decodeOptions fills dst with the options of the interaction, following the specs
+func decodeOptions(data discord.SlashCommandInteractionData, specs []optionSpec, dst reflect.Value) error {
+	for _, spec := range specs {
+		field := dst.Field(spec.Field)
+
+		value, ok := optionValue(data, spec, field.Type())
+		if !ok {
+			if spec.Required {
+				return middlewares.NewValidationError(missingOptionMessage.With(i18n.Vars{"option": spec.Name}))
+			}
+			continue
+		}
+		if err := spec.validate(value); err != nil {
+			return err
+		}
+
+		if spec.Pointer {
+			ptr := reflect.New(field.Type().Elem())
+			ptr.Elem().Set(value.Convert(field.Type().Elem()))
+			field.Set(ptr)
+		} else {
+			field.Set(value.Convert(field.Type()))
+		}
+	}
+
+	if validator, ok := dst.Addr().Interface().(Validator); ok {
+		if err := validator.Validate(); err != nil {
+			var userErr *middlewares.UserError
+			if errors.As(err, &userErr) {
+				return err
+			}
+			return &middlewares.UserError{Kind: middlewares.ErrorKindValidation, Err: err}
+		}
+	}
+	return nil
+}
+
+func optionValue(data discord.SlashCommandInteractionData, spec optionSpec, fieldType reflect.Type) (reflect.Value, bool) {
+	if spec.Pointer {
+		fieldType = fieldType.Elem()
+	}
+	var value any
+	var ok bool
+	switch spec.Type {
+	case discord.ApplicationCommandOptionTypeString:
+		value, ok = data.OptString(spec.Name)
+	case discord.ApplicationCommandOptionTypeInt:
+		value, ok = data.OptInt(spec.Name)
+	case discord.ApplicationCommandOptionTypeFloat:
+		value, ok = data.OptFloat(spec.Name)
+	case discord.ApplicationCommandOptionTypeBool:
+		value, ok = data.OptBool(spec.Name)
+	case discord.ApplicationCommandOptionTypeUser:
+		if fieldType == memberType {
+			value, ok = data.OptMember(spec.Name)
+		} else {
+			value, ok = data.OptUser(spec.Name)
+		}
+	case discord.ApplicationCommandOptionTypeRole:
+		value, ok = data.OptRole(spec.Name)
+	case discord.ApplicationCommandOptionTypeChannel:
+		value, ok = data.OptChannel(spec.Name)
+	case discord.ApplicationCommandOptionTypeAttachment:
+		value, ok = data.OptAttachment(spec.Name)
+	}
+	if !ok {
+		return reflect.Value{}, false
+	}
+	return reflect.ValueOf(value), true
+}
+
+// validate checks the decoded value against the choices and bounds of the spec
+func (s optionSpec) validate(value reflect.Value) error {
+	if len(s.Choices) > 0 {
+		valid := slices.ContainsFunc(s.Choices, func(c choice) bool { return c.Parsed == value.Interface() })
+		if !valid {
+			return middlewares.NewValidationError(invalidChoiceMessage.With(i18n.Vars{"value": fmt.Sprint(value.Interface()), "option": s.Name}))
+		}
+	}
+
+	if s.Min == nil && s.Max == nil {
+		return nil
+	}
+	var n float64
+	switch value.Kind() {
+	case reflect.String:
+		n = float64(len([]rune(value.String())))
+	case reflect.Int, reflect.Int64:
+		n = float64(value.Int())
+	case reflect.Float64:
+		n = value.Float()
+	default:
+		return nil
+	}
+	if (s.Min != nil && n < *s.Min) || (s.Max != nil && n > *s.Max) {
+		return middlewares.NewValidationError(outOfRangeMessage.With(i18n.Vars{"option": s.Name}))
+	}
+	return nil
+}
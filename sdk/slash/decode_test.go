@@ -0,0 +1,51 @@
+package slash
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+func TestValidateChoices(t *testing.T) {
+	tests := []struct {
+		name    string
+		optType discord.ApplicationCommandOptionType
+		choices []string
+		value   any
+		valid   bool
+	}{
+		{"string choice", discord.ApplicationCommandOptionTypeString, []string{"red", "blue"}, "blue", true},
+		{"unknown string", discord.ApplicationCommandOptionTypeString, []string{"red", "blue"}, "green", false},
+		{"int choice", discord.ApplicationCommandOptionTypeInt, []string{"1", "2"}, 2, true},
+		{"unknown int", discord.ApplicationCommandOptionTypeInt, []string{"1", "2"}, 3, false},
+		{"float written with a trailing zero", discord.ApplicationCommandOptionTypeFloat, []string{"1.0", "0.50"}, 1.0, true},
+		{"float written with a leading zero", discord.ApplicationCommandOptionTypeFloat, []string{"1.0", "0.50"}, 0.5, true},
+		{"unknown float", discord.ApplicationCommandOptionTypeFloat, []string{"1.0", "0.50"}, 0.25, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := optionSpec{Name: "option", Type: tt.optType}
+			for _, value := range tt.choices {
+				parsed, err := parseChoice(tt.optType, value)
+				if err != nil {
+					t.Fatalf("parseChoice(%q) failed: %v", value, err)
+				}
+				spec.Choices = append(spec.Choices, choice{Value: value, Parsed: parsed})
+			}
+			err := spec.validate(reflect.ValueOf(tt.value))
+			if valid := err == nil; valid != tt.valid {
+				t.Errorf("validate(%v) = %v, want valid %v", tt.value, err, tt.valid)
+			}
+		})
+	}
+}
+
+func TestParseChoiceInvalid(t *testing.T) {
+	if _, err := parseChoice(discord.ApplicationCommandOptionTypeInt, "1.5"); err == nil {
+		t.Error("parseChoice accepted '1.5' as an int")
+	}
+	if _, err := parseChoice(discord.ApplicationCommandOptionTypeFloat, "one"); err == nil {
+		t.Error("parseChoice accepted 'one' as a float")
+	}
+}
@@ -0,0 +1,360 @@
+package slash
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/bil0u/galaxy-os/sdk/i18n"
+	"github.com/disgoorg/disgo/discord"
+)
+
+// Struct tags understood when building options from a struct:
+//
+//	name           option name, fields without it are ignored
+//	required       "true" if the option must be provided
+//	autocomplete   "true" if the option uses an autocomplete handler
+//	choices        comma separated list of choices, either "value" or "name=value"
+//	min, max       min/max value for numbers, min/max length for strings
+//	channel_types  comma separated list of channel types (text, voice, category, news, forum, stage, thread, media)
+//
+// Descriptions and localizations are read from the catalogs, under "<command key>.options.<name>":
+// "name" and "description" for the option, "choices.<value>" for the choice names.
+const (
+	tagName         = "name"
+	tagRequired     = "required"
+	tagAutocomplete = "autocomplete"
+	tagChoices      = "choices"
+	tagMin          = "min"
+	tagMax          = "max"
+	tagChannelTypes = "channel_types"
+)
+
+var channelTypeNames = map[string][]discord.ChannelType{
+	"text":     {discord.ChannelTypeGuildText},
+	"voice":    {discord.ChannelTypeGuildVoice},
+	"category": {discord.ChannelTypeGuildCategory},
+	"news":     {discord.ChannelTypeGuildNews},
+	"forum":    {discord.ChannelTypeGuildForum},
+	"stage":    {discord.ChannelTypeGuildStageVoice},
+	"media":    {discord.ChannelTypeGuildMedia},
+	"thread": {
+		discord.ChannelTypeGuildNewsThread,
+		discord.ChannelTypeGuildPublicThread,
+		discord.ChannelTypeGuildPrivateThread,
+	},
+}
+
+var (
+	userType       = reflect.TypeOf(discord.User{})
+	memberType     = reflect.TypeOf(discord.ResolvedMember{})
+	roleType       = reflect.TypeOf(discord.Role{})
+	channelType    = reflect.TypeOf(discord.ResolvedChannel{})
+	attachmentType = reflect.TypeOf(discord.Attachment{})
+)
+
+type choice struct {
+	Name              string
+	NameLocalizations map[discord.Locale]string
+	Value             string
+	// Parsed is the value in the type of the option: string, int or float64
+	Parsed any
+}
+
+// optionSpec is the parsed representation of a tagged struct field
+type optionSpec struct {
+	Field                    int
+	Name                     string
+	NameLocalizations        map[discord.Locale]string
+	Description              string
+	DescriptionLocalizations map[discord.Locale]string
+	Type                     discord.ApplicationCommandOptionType
+	Required                 bool
+	Autocomplete             bool
+	Choices                  []choice
+	Min                      *float64
+	Max                      *float64
+	ChannelTypes             []discord.ChannelType
+	// Pointer is true when the field is a pointer, which is left nil when the option is absent
+	Pointer bool
+}
+
+// parseOptions reads the option specs from the fields of the given struct type,
+// keyPrefix is the catalog prefix of the command options
+func parseOptions(t reflect.Type, keyPrefix string) ([]optionSpec, error) {
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("options must be a struct, got %s", t.Kind())
+	}
+
+	var specs []optionSpec
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		if _, ok := field.Tag.Lookup(tagName); !ok {
+			continue
+		}
+		spec, err := parseOption(i, field, keyPrefix)
+		if err != nil {
+			return nil, fmt.Errorf("field '%s': %w", field.Name, err)
+		}
+		specs = append(specs, spec)
+	}
+
+	// Discord requires required options to be listed first
+	for i := 1; i < len(specs); i++ {
+		if specs[i].Required && !specs[i-1].Required {
+			return nil, fmt.Errorf("required option '%s' must be declared before optional ones", specs[i].Name)
+		}
+	}
+	return specs, nil
+}
+
+func parseOption(index int, field reflect.StructField, keyPrefix string) (optionSpec, error) {
+	name := field.Tag.Get(tagName)
+	key := fmt.Sprintf("%s.%s", keyPrefix, name)
+	nameKey := i18n.Declare(key + ".name")
+	descriptionKey := i18n.Declare(key + ".description")
+	spec := optionSpec{
+		Field:                    index,
+		Name:                     name,
+		NameLocalizations:        nameKey.Localizations(),
+		Description:              descriptionKey.Default(),
+		DescriptionLocalizations: descriptionKey.Localizations(),
+		Required:                 field.Tag.Get(tagRequired) == "true",
+		Autocomplete:             field.Tag.Get(tagAutocomplete) == "true",
+	}
+	if _, ok := i18n.Lookup(i18n.DefaultLocale, string(descriptionKey)); !ok {
+		return spec, fmt.Errorf("missing description '%s' in the default catalog", descriptionKey)
+	}
+
+	fieldType := field.Type
+	if fieldType.Kind() == reflect.Pointer {
+		spec.Pointer = true
+		fieldType = fieldType.Elem()
+	}
+
+	optType, err := optionType(fieldType)
+	if err != nil {
+		return spec, err
+	}
+	spec.Type = optType
+
+	if spec.Required && spec.Pointer {
+		return spec, fmt.Errorf("required options can't be pointers")
+	}
+
+	// Choices
+	if raw, ok := field.Tag.Lookup(tagChoices); ok {
+		if !canHaveChoices(optType) {
+			return spec, fmt.Errorf("choices are not supported for %s fields", fieldType)
+		}
+		for _, part := range strings.Split(raw, ",") {
+			choiceName, value, found := strings.Cut(part, "=")
+			if !found {
+				value = choiceName
+			}
+			value = strings.TrimSpace(value)
+			parsed, err := parseChoice(optType, value)
+			if err != nil {
+				return spec, fmt.Errorf("invalid choice value '%s': %w", value, err)
+			}
+			c := choice{Name: strings.TrimSpace(choiceName), Value: value, Parsed: parsed}
+			choiceKey := fmt.Sprintf("%s.choices.%s", key, value)
+			if _, ok := i18n.Lookup(i18n.DefaultLocale, choiceKey); ok {
+				c.Name = i18n.Declare(choiceKey).Default()
+				c.NameLocalizations = i18n.Key(choiceKey).Localizations()
+			}
+			spec.Choices = append(spec.Choices, c)
+		}
+		if spec.Autocomplete {
+			return spec, fmt.Errorf("choices and autocomplete can't be used together")
+		}
+	}
+
+	// Bounds
+	for _, bound := range []struct {
+		tag string
+		dst **float64
+	}{{tagMin, &spec.Min}, {tagMax, &spec.Max}} {
+		raw, ok := field.Tag.Lookup(bound.tag)
+		if !ok {
+			continue
+		}
+		if !canHaveBounds(optType) {
+			return spec, fmt.Errorf("%s is not supported for %s fields", bound.tag, fieldType)
+		}
+		value, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return spec, fmt.Errorf("invalid %s '%s': %w", bound.tag, raw, err)
+		}
+		*bound.dst = &value
+	}
+
+	// Channel types
+	if raw, ok := field.Tag.Lookup(tagChannelTypes); ok {
+		if optType != discord.ApplicationCommandOptionTypeChannel {
+			return spec, fmt.Errorf("channel_types is only supported for channel options")
+		}
+		for _, name := range strings.Split(raw, ",") {
+			types, ok := channelTypeNames[strings.TrimSpace(name)]
+			if !ok {
+				return spec, fmt.Errorf("unknown channel type '%s'", name)
+			}
+			spec.ChannelTypes = append(spec.ChannelTypes, types...)
+		}
+	}
+
+	if spec.Autocomplete && !canHaveChoices(optType) {
+		return spec, fmt.Errorf("autocomplete is not supported for %s fields", fieldType)
+	}
+	return spec, nil
+}
+
+// parseChoice returns the value of a choice in the type of the option, so that "1.0" and "1" are the same float choice
+func parseChoice(t discord.ApplicationCommandOptionType, value string) (any, error) {
+	switch t {
+	case discord.ApplicationCommandOptionTypeInt:
+		return strconv.Atoi(value)
+	case discord.ApplicationCommandOptionTypeFloat:
+		return strconv.ParseFloat(value, 64)
+	}
+	return value, nil
+}
+
+func optionType(t reflect.Type) (discord.ApplicationCommandOptionType, error) {
+	switch t {
+	case userType, memberType:
+		return discord.ApplicationCommandOptionTypeUser, nil
+	case roleType:
+		return discord.ApplicationCommandOptionTypeRole, nil
+	case channelType:
+		return discord.ApplicationCommandOptionTypeChannel, nil
+	case attachmentType:
+		return discord.ApplicationCommandOptionTypeAttachment, nil
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return discord.ApplicationCommandOptionTypeString, nil
+	case reflect.Int, reflect.Int64:
+		return discord.ApplicationCommandOptionTypeInt, nil
+	case reflect.Float64:
+		return discord.ApplicationCommandOptionTypeFloat, nil
+	case reflect.Bool:
+		return discord.ApplicationCommandOptionTypeBool, nil
+	}
+	return 0, fmt.Errorf("unsupported option type %s", t)
+}
+
+func canHaveChoices(t discord.ApplicationCommandOptionType) bool {
+	return t == discord.ApplicationCommandOptionTypeString ||
+		t == discord.ApplicationCommandOptionTypeInt ||
+		t == discord.ApplicationCommandOptionTypeFloat
+}
+
+func canHaveBounds(t discord.ApplicationCommandOptionType) bool {
+	return canHaveChoices(t)
+}
+
+func intPtr(f *float64) *int {
+	if f == nil {
+		return nil
+	}
+	i := int(*f)
+	return &i
+}
+
+// create returns the discord option matching the spec
+func (s optionSpec) create() discord.ApplicationCommandOption {
+	switch s.Type {
+	case discord.ApplicationCommandOptionTypeString:
+		opt := discord.ApplicationCommandOptionString{
+			Name:                     s.Name,
+			NameLocalizations:        s.NameLocalizations,
+			Description:              s.Description,
+			DescriptionLocalizations: s.DescriptionLocalizations,
+			Required:                 s.Required,
+			Autocomplete:             s.Autocomplete,
+			MinLength:                intPtr(s.Min),
+			MaxLength:                intPtr(s.Max),
+		}
+		for _, c := range s.Choices {
+			opt.Choices = append(opt.Choices, discord.ApplicationCommandOptionChoiceString{Name: c.Name, NameLocalizations: c.NameLocalizations, Value: c.Value})
+		}
+		return opt
+	case discord.ApplicationCommandOptionTypeInt:
+		opt := discord.ApplicationCommandOptionInt{
+			Name:                     s.Name,
+			NameLocalizations:        s.NameLocalizations,
+			Description:              s.Description,
+			DescriptionLocalizations: s.DescriptionLocalizations,
+			Required:                 s.Required,
+			Autocomplete:             s.Autocomplete,
+			MinValue:                 intPtr(s.Min),
+			MaxValue:                 intPtr(s.Max),
+		}
+		for _, c := range s.Choices {
+			opt.Choices = append(opt.Choices, discord.ApplicationCommandOptionChoiceInt{Name: c.Name, NameLocalizations: c.NameLocalizations, Value: c.Parsed.(int)})
+		}
+		return opt
+	case discord.ApplicationCommandOptionTypeFloat:
+		opt := discord.ApplicationCommandOptionFloat{
+			Name:                     s.Name,
+			NameLocalizations:        s.NameLocalizations,
+			Description:              s.Description,
+			DescriptionLocalizations: s.DescriptionLocalizations,
+			Required:                 s.Required,
+			Autocomplete:             s.Autocomplete,
+			MinValue:                 s.Min,
+			MaxValue:                 s.Max,
+		}
+		for _, c := range s.Choices {
+			opt.Choices = append(opt.Choices, discord.ApplicationCommandOptionChoiceFloat{Name: c.Name, NameLocalizations: c.NameLocalizations, Value: c.Parsed.(float64)})
+		}
+		return opt
+	case discord.ApplicationCommandOptionTypeBool:
+		return discord.ApplicationCommandOptionBool{
+			Name:                     s.Name,
+			NameLocalizations:        s.NameLocalizations,
+			Description:              s.Description,
+			DescriptionLocalizations: s.DescriptionLocalizations,
+			Required:                 s.Required,
+		}
+	case discord.ApplicationCommandOptionTypeUser:
+		return discord.ApplicationCommandOptionUser{
+			Name:                     s.Name,
+			NameLocalizations:        s.NameLocalizations,
+			Description:              s.Description,
+			DescriptionLocalizations: s.DescriptionLocalizations,
+			Required:                 s.Required,
+		}
+	case discord.ApplicationCommandOptionTypeRole:
+		return discord.ApplicationCommandOptionRole{
+			Name:                     s.Name,
+			NameLocalizations:        s.NameLocalizations,
+			Description:              s.Description,
+			DescriptionLocalizations: s.DescriptionLocalizations,
+			Required:                 s.Required,
+		}
+	case discord.ApplicationCommandOptionTypeChannel:
+		return discord.ApplicationCommandOptionChannel{
+			Name:                     s.Name,
+			NameLocalizations:        s.NameLocalizations,
+			Description:              s.Description,
+			DescriptionLocalizations: s.DescriptionLocalizations,
+			Required:                 s.Required,
+			ChannelTypes:             s.ChannelTypes,
+		}
+	case discord.ApplicationCommandOptionTypeAttachment:
+		return discord.ApplicationCommandOptionAttachment{
+			Name:                     s.Name,
+			NameLocalizations:        s.NameLocalizations,
+			Description:              s.Description,
+			DescriptionLocalizations: s.DescriptionLocalizations,
+			Required:                 s.Required,
+		}
+	}
+	return nil
+}
@@ -0,0 +1,250 @@
+package slash
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/bil0u/galaxy-os/sdk/i18n"
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/handler"
+	"github.com/disgoorg/json"
+)
+
+// Node is a subcommand or a subcommand group of a Tree
+type Node interface {
+	nodeName() string
+}
+
+type subcommandNode struct {
+	name    string
+	options reflect.Type
+}
+
+func (n subcommandNode) nodeName() string { return n.name }
+
+type groupNode struct {
+	name     string
+	children []Node
+}
+
+func (n groupNode) nodeName() string { return n.name }
+
+// Sub declares a subcommand whose options are declared by the tagged fields of T
+func Sub[T any](name string) Node {
+	return subcommandNode{name: name, options: reflect.TypeFor[T]()}
+}
+
+// Group declares a subcommand group, holding subcommands only
+func Group(name string, subcommands ...Node) Node {
+	return groupNode{name: name, children: subcommands}
+}
+
+type boundHandler struct {
+	options reflect.Type
+	handle  func(e *handler.CommandEvent, specs []optionSpec) error
+}
+
+// Tree is a slash command made of subcommands and subcommand groups, e.g. /admin roles sync.
+// The tree is declared once, it builds both the discord command and the routes of its subcommands.
+// Names and descriptions are read from the catalogs: "<Key>.name" for the command,
+// "<Key>.<group>.name" for groups and "<Key>[.<group>].<subcommand>.name" for subcommands
+type Tree struct {
+	Key                      string
+	DefaultMemberPermissions *discord.Permissions
+	Nodes                    []Node
+	handlers                 map[string]boundHandler
+}
+
+func NewTree(key string, nodes ...Node) *Tree {
+	return &Tree{
+		Key:      key,
+		Nodes:    nodes,
+		handlers: map[string]boundHandler{},
+	}
+}
+
+// Handle binds a handler to the subcommand at the given path, e.g. "/admin/roles/sync".
+// The path and the options type are checked when the tree is registered
+func Handle[T any](t *Tree, path string, h func(e *handler.CommandEvent, options T) error) {
+	t.handlers[path] = boundHandler{
+		options: reflect.TypeFor[T](),
+		handle: func(e *handler.CommandEvent, specs []optionSpec) error {
+			var options T
+			if err := decodeOptions(e.SlashCommandInteractionData(), specs, reflect.ValueOf(&options).Elem()); err != nil {
+				return err
+			}
+			return h(e, options)
+		},
+	}
+}
+
+// leaf is a subcommand with its path and catalog key
+type leaf struct {
+	path    string
+	key     string
+	options reflect.Type
+}
+
+func (t *Tree) rootName() string {
+	return i18n.Key(t.Key + ".name").Default()
+}
+
+// leaves returns the subcommands of the tree
+func (t *Tree) leaves() ([]leaf, error) {
+	var leaves []leaf
+	root := "/" + t.rootName()
+	for _, node := range t.Nodes {
+		switch n := node.(type) {
+		case subcommandNode:
+			leaves = append(leaves, leaf{path: root + "/" + n.name, key: t.Key + "." + n.name, options: n.options})
+		case groupNode:
+			for _, child := range n.children {
+				sub, ok := child.(subcommandNode)
+				if !ok {
+					return nil, fmt.Errorf("group '%s' can only hold subcommands, '%s' is not one", n.name, child.nodeName())
+				}
+				leaves = append(leaves, leaf{
+					path:    root + "/" + n.name + "/" + sub.name,
+					key:     t.Key + "." + n.name + "." + sub.name,
+					options: sub.options,
+				})
+			}
+		}
+	}
+	return leaves, nil
+}
+
+// Build returns the discord command matching the tree
+func (t *Tree) Build() (discord.SlashCommandCreate, error) {
+	nameKey := i18n.Declare(t.Key + ".name")
+	descriptionKey := i18n.Declare(t.Key + ".description")
+	create := discord.SlashCommandCreate{
+		Name:                     nameKey.Default(),
+		NameLocalizations:        nameKey.Localizations(),
+		Description:              descriptionKey.Default(),
+		DescriptionLocalizations: descriptionKey.Localizations(),
+	}
+	if t.DefaultMemberPermissions != nil {
+		create.DefaultMemberPermissions = json.NewNullablePtr(*t.DefaultMemberPermissions)
+	}
+
+	for _, node := range t.Nodes {
+		switch n := node.(type) {
+		case subcommandNode:
+			sub, err := buildSubcommand(t.Key+"."+n.name, n)
+			if err != nil {
+				return create, err
+			}
+			create.Options = append(create.Options, sub)
+		case groupNode:
+			key := t.Key + "." + n.name
+			group := discord.ApplicationCommandOptionSubCommandGroup{
+				Name:                     n.name,
+				NameLocalizations:        i18n.Declare(key + ".name").Localizations(),
+				Description:              i18n.Declare(key + ".description").Default(),
+				DescriptionLocalizations: i18n.Key(key + ".description").Localizations(),
+			}
+			for _, child := range n.children {
+				subNode, ok := child.(subcommandNode)
+				if !ok {
+					return create, fmt.Errorf("group '%s' can only hold subcommands, '%s' is not one", n.name, child.nodeName())
+				}
+				sub, err := buildSubcommand(key+"."+subNode.name, subNode)
+				if err != nil {
+					return create, err
+				}
+				group.Options = append(group.Options, sub)
+			}
+			create.Options = append(create.Options, group)
+		}
+	}
+	return create, nil
+}
+
+// MustBuild is like Build but panics if the tree is invalid
+func (t *Tree) MustBuild() discord.SlashCommandCreate {
+	create, err := t.Build()
+	if err != nil {
+		panic(err)
+	}
+	return create
+}
+
+func buildSubcommand(key string, n subcommandNode) (discord.ApplicationCommandOptionSubCommand, error) {
+	sub := discord.ApplicationCommandOptionSubCommand{
+		Name:                     n.name,
+		NameLocalizations:        i18n.Declare(key + ".name").Localizations(),
+		Description:              i18n.Declare(key + ".description").Default(),
+		DescriptionLocalizations: i18n.Key(key + ".description").Localizations(),
+	}
+	specs, err := parseOptions(n.options, key+".options")
+	if err != nil {
+		return sub, fmt.Errorf("invalid options for subcommand '%s': %w", key, err)
+	}
+	for _, spec := range specs {
+		sub.Options = append(sub.Options, spec.create())
+	}
+	return sub, nil
+}
+
+// Verify checks that every subcommand has a handler with the right options type,
+// and that every handler is bound to an existing subcommand
+func (t *Tree) Verify() error {
+	leaves, err := t.leaves()
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	paths := make([]string, 0, len(leaves))
+	for _, l := range leaves {
+		paths = append(paths, l.path)
+		bound, ok := t.handlers[l.path]
+		if !ok {
+			errs = append(errs, fmt.Errorf("subcommand '%s' has no handler", l.path))
+			continue
+		}
+		if bound.options != l.options {
+			errs = append(errs, fmt.Errorf("handler of '%s' expects options %s, subcommand declares %s", l.path, bound.options, l.options))
+		}
+	}
+	for _, path := range slices.Sorted(maps.Keys(t.handlers)) {
+		if !slices.Contains(paths, path) {
+			errs = append(errs, fmt.Errorf("handler '%s' is orphaned, no such subcommand (known: %s)", path, strings.Join(paths, ", ")))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Register verifies the tree then registers a route for each of its subcommands
+func (t *Tree) Register(r handler.Router) error {
+	if err := t.Verify(); err != nil {
+		return fmt.Errorf("invalid command tree '%s': %w", t.Key, err)
+	}
+	leaves, err := t.leaves()
+	if err != nil {
+		return err
+	}
+	for _, l := range leaves {
+		specs, err := parseOptions(l.options, l.key+".options")
+		if err != nil {
+			return fmt.Errorf("invalid options for subcommand '%s': %w", l.key, err)
+		}
+		bound := t.handlers[l.path]
+		r.Command(l.path, func(e *handler.CommandEvent) error {
+			return bound.handle(e, specs)
+		})
+	}
+	return nil
+}
+
+// MustRegister is like Register but panics if the tree is invalid, to fail at startup
+func (t *Tree) MustRegister(r handler.Router) {
+	if err := t.Register(r); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,119 @@
+package slash
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/disgoorg/disgo/handler"
+)
+
+type treeNoOptions struct{}
+
+type treeOptions struct {
+	Name string `name:"name"`
+}
+
+func noop[T any](*handler.CommandEvent, T) error { return nil }
+
+// newTestTree returns a tree named after the /admin command of the catalogs, so that its paths start with /admin
+func newTestTree() *Tree {
+	return NewTree("commands.admin",
+		Group("roles",
+			Sub[treeOptions]("sync"),
+		),
+		Sub[treeNoOptions]("status"),
+	)
+}
+
+func TestTreeVerify(t *testing.T) {
+	tests := []struct {
+		name   string
+		bind   func(tree *Tree)
+		errors []string
+	}{
+		{
+			name: "every subcommand bound",
+			bind: func(tree *Tree) {
+				Handle(tree, "/admin/roles/sync", noop[treeOptions])
+				Handle(tree, "/admin/status", noop[treeNoOptions])
+			},
+		},
+		{
+			name: "missing handler",
+			bind: func(tree *Tree) {
+				Handle(tree, "/admin/roles/sync", noop[treeOptions])
+			},
+			errors: []string{"subcommand '/admin/status' has no handler"},
+		},
+		{
+			name: "orphan handler",
+			bind: func(tree *Tree) {
+				Handle(tree, "/admin/roles/sync", noop[treeOptions])
+				Handle(tree, "/admin/status", noop[treeNoOptions])
+				Handle(tree, "/admin/roles/purge", noop[treeNoOptions])
+			},
+			errors: []string{"handler '/admin/roles/purge' is orphaned"},
+		},
+		{
+			name: "handler bound to a group",
+			bind: func(tree *Tree) {
+				Handle(tree, "/admin/roles", noop[treeOptions])
+				Handle(tree, "/admin/status", noop[treeNoOptions])
+			},
+			errors: []string{"subcommand '/admin/roles/sync' has no handler", "handler '/admin/roles' is orphaned"},
+		},
+		{
+			name: "wrong options",
+			bind: func(tree *Tree) {
+				Handle(tree, "/admin/roles/sync", noop[treeNoOptions])
+				Handle(tree, "/admin/status", noop[treeNoOptions])
+			},
+			errors: []string{"handler of '/admin/roles/sync' expects options slash.treeNoOptions, subcommand declares slash.treeOptions"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tree := newTestTree()
+			tt.bind(tree)
+			err := tree.Verify()
+			if len(tt.errors) == 0 {
+				if err != nil {
+					t.Fatalf("Verify() = %v, want no error", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("Verify() = nil, want %q", tt.errors)
+			}
+			for _, expected := range tt.errors {
+				if !strings.Contains(err.Error(), expected) {
+					t.Errorf("Verify() = %v, want it to contain %q", err, expected)
+				}
+			}
+			if got := len(strings.Split(err.Error(), "\n")); got != len(tt.errors) {
+				t.Errorf("Verify() returned %d errors, want %d: %v", got, len(tt.errors), err)
+			}
+		})
+	}
+}
+
+func TestTreeVerifyNestedGroups(t *testing.T) {
+	tree := NewTree("commands.admin", Group("roles", Group("nested", Sub[treeNoOptions]("sync"))))
+	if err := tree.Verify(); err == nil || !strings.Contains(err.Error(), "group 'roles' can only hold subcommands") {
+		t.Errorf("Verify() = %v, want a nested group error", err)
+	}
+}
+
+func TestTreeRegister(t *testing.T) {
+	// Options need descriptions in the catalogs to be registered
+	tree := NewTree("commands.admin", Group("roles", Sub[treeNoOptions]("sync")), Sub[treeNoOptions]("status"))
+	Handle(tree, "/admin/status", noop[treeNoOptions])
+	if err := tree.Register(handler.New()); err == nil {
+		t.Error("Register accepted a tree with a subcommand without handler")
+	}
+
+	Handle(tree, "/admin/roles/sync", noop[treeNoOptions])
+	if err := tree.Register(handler.New()); err != nil {
+		t.Errorf("Register() = %v, want no error", err)
+	}
+}
@@ -0,0 +1,130 @@
+package snapshot
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/rest"
+	"github.com/disgoorg/snowflake/v2"
+)
+
+// ErrNoCommands is returned when the commands of a bot can't be read, e.g. when the bot is not configured
+var ErrNoCommands = errors.New("no commands for this bot")
+
+// Application is a bot whose registered commands are read by the generators
+type Application struct {
+	Bot   string
+	ID    snowflake.ID
+	Token string
+	// Guilds are the guilds the bot syncs its commands to, its commands are global if there are none
+	Guilds []snowflake.ID
+}
+
+// Commands are the application commands registered by a bot, globally and in the guilds it syncs them to
+type Commands struct {
+	Version       int                 `json:"version"`
+	Bot           string              `json:"bot"`
+	ApplicationID snowflake.ID        `json:"application_id"`
+	Global        ApplicationCommands `json:"global"`
+	Guilds        []GuildCommands     `json:"guilds"`
+}
+
+// GuildCommands are the application commands registered by a bot in a guild
+type GuildCommands struct {
+	ID       snowflake.ID        `json:"id"`
+	Name     string              `json:"name"`
+	Commands ApplicationCommands `json:"commands"`
+}
+
+// ApplicationCommands are application commands, read back with their concrete type
+type ApplicationCommands []discord.ApplicationCommand
+
+func (c *ApplicationCommands) UnmarshalJSON(data []byte) error {
+	var commands []discord.UnmarshalApplicationCommand
+	if err := json.Unmarshal(data, &commands); err != nil {
+		return err
+	}
+	*c = make(ApplicationCommands, len(commands))
+	for i, command := range commands {
+		(*c)[i] = command.ApplicationCommand
+	}
+	return nil
+}
+
+func (s *restSource) Commands(bot string) (*Commands, error) {
+	app := s.applications[bot]
+	if app == nil {
+		return nil, fmt.Errorf("%w: bot '%s' is not configured", ErrNoCommands, bot)
+	}
+
+	// Each bot uses its own token
+	client := rest.New(rest.NewClient(app.Token))
+	global, err := client.GetGlobalCommands(app.ID, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch global commands of bot '%s': %w", bot, err)
+	}
+	commands := &Commands{
+		Version:       Version,
+		Bot:           bot,
+		ApplicationID: app.ID,
+		Global:        sortCommands(global),
+	}
+	for _, guildID := range app.Guilds {
+		guild, err := client.GetGuild(guildID, false)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch guild '%s' of bot '%s': %w", guildID, bot, err)
+		}
+		guildCommands, err := client.GetGuildCommands(app.ID, guildID, false)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch commands of bot '%s' in guild '%s': %w", bot, guildID, err)
+		}
+		commands.Guilds = append(commands.Guilds, GuildCommands{ID: guildID, Name: guild.Name, Commands: sortCommands(guildCommands)})
+	}
+	return commands, nil
+}
+
+// sortCommands sorts commands by ID, which makes snapshots of unchanged commands identical
+func sortCommands(commands []discord.ApplicationCommand) ApplicationCommands {
+	slices.SortFunc(commands, func(a, b discord.ApplicationCommand) int { return cmp.Compare(a.ID(), b.ID()) })
+	return commands
+}
+
+func (s dirSource) Commands(bot string) (*Commands, error) {
+	commands, err := LoadCommands(CommandsPath(s.dir, bot))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %w", ErrNoCommands, err)
+	}
+	return commands, err
+}
+
+// CommandsPath returns the path of the snapshot of the commands of a bot in a directory
+func CommandsPath(dir string, bot string) string {
+	return filepath.Join(dir, "commands."+bot+".json")
+}
+
+// SaveCommands writes the snapshot of the commands of a bot in a directory, the directory is created if needed
+func SaveCommands(dir string, commands *Commands) error {
+	data, err := json.MarshalIndent(commands, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode snapshot of the commands of bot '%s': %w", commands.Bot, err)
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(CommandsPath(dir, commands.Bot), append(data, '\n'), 0644)
+}
+
+// LoadCommands reads a snapshot of commands, it fails if the snapshot was written in another format version
+func LoadCommands(path string) (*Commands, error) {
+	commands := new(Commands)
+	if err := load(path, commands); err != nil {
+		return nil, err
+	}
+	return commands, nil
+}
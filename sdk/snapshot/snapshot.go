@@ -0,0 +1,220 @@
+package snapshot
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/disgoorg/disgo/bot"
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/snowflake/v2"
+)
+
+// Version is the version of the snapshot format, it changes when snapshots can't be read as before
+const Version = 3
+
+// ErrUnsupportedVersion is returned when reading a snapshot written in another format version
+var ErrUnsupportedVersion = errors.New("unsupported snapshot version")
+
+// Guild is the state of a guild: its roles, channels with their permission overwrites,
+// emojis, stickers, scheduled events and members, if they were fetched. It has no date, so that snapshots of an unchanged guild are identical
+type Guild struct {
+	Version         int                           `json:"version"`
+	ID              snowflake.ID                  `json:"id"`
+	Name            string                        `json:"name"`
+	Roles           []discord.Role                `json:"roles"`
+	Channels        Channels                      `json:"channels"`
+	Emojis          []discord.Emoji               `json:"emojis"`
+	Stickers        []discord.Sticker             `json:"stickers"`
+	ScheduledEvents []discord.GuildScheduledEvent `json:"scheduled_events"`
+	Members         []discord.Member              `json:"members"`
+}
+
+// Channels are guild channels, read back with their concrete type
+type Channels []discord.GuildChannel
+
+func (c *Channels) UnmarshalJSON(data []byte) error {
+	var channels []discord.UnmarshalChannel
+	if err := json.Unmarshal(data, &channels); err != nil {
+		return err
+	}
+	*c = make(Channels, 0, len(channels))
+	for _, channel := range channels {
+		guildChannel, ok := channel.Channel.(discord.GuildChannel)
+		if !ok {
+			return fmt.Errorf("channel '%s' is not a guild channel", channel.ID())
+		}
+		*c = append(*c, guildChannel)
+	}
+	return nil
+}
+
+// Source provides the state of the guilds and the commands of the bots the generators read
+type Source interface {
+	Guild(guildID snowflake.ID) (*Guild, error)
+	// Commands returns the commands registered by a bot, or an ErrNoCommands error if they can't be read
+	Commands(bot string) (*Commands, error)
+}
+
+// restSource fetches the guilds and commands from discord, each guild is fetched once
+type restSource struct {
+	client       bot.Client
+	applications map[string]*Application
+	guilds       map[snowflake.ID]*Guild
+	// withMembers fetches the members of the guilds, they are left out otherwise
+	withMembers bool
+}
+
+// NewRestSource returns a source fetching the guilds from discord, and the commands of the given applications.
+// Members are only fetched if withMembers is set, as they need the privileged members intent and are personal data
+func NewRestSource(client bot.Client, withMembers bool, applications ...Application) Source {
+	s := &restSource{client: client, applications: map[string]*Application{}, guilds: map[snowflake.ID]*Guild{}, withMembers: withMembers}
+	for _, app := range applications {
+		s.applications[app.Bot] = &app
+	}
+	return s
+}
+
+func (s *restSource) Guild(guildID snowflake.ID) (*Guild, error) {
+	if guild, ok := s.guilds[guildID]; ok {
+		return guild, nil
+	}
+	rest := s.client.Rest()
+	restGuild, err := rest.GetGuild(guildID, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch guild '%s': %w", guildID, err)
+	}
+	roles, err := rest.GetRoles(guildID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch roles of guild '%s': %w", guildID, err)
+	}
+	channels, err := rest.GetGuildChannels(guildID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch channels of guild '%s': %w", guildID, err)
+	}
+	emojis, err := rest.GetEmojis(guildID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch emojis of guild '%s': %w", guildID, err)
+	}
+	stickers, err := rest.GetStickers(guildID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch stickers of guild '%s': %w", guildID, err)
+	}
+	scheduledEvents, err := rest.GetGuildScheduledEvents(guildID, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch scheduled events of guild '%s': %w", guildID, err)
+	}
+	var members []discord.Member
+	if s.withMembers {
+		members, err = s.members(guildID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch members of guild '%s': %w", guildID, err)
+		}
+	}
+
+	// Sorting by ID makes snapshots of an unchanged guild identical
+	slices.SortFunc(roles, func(a, b discord.Role) int { return cmp.Compare(a.ID, b.ID) })
+	slices.SortFunc(channels, func(a, b discord.GuildChannel) int { return cmp.Compare(a.ID(), b.ID()) })
+	slices.SortFunc(emojis, func(a, b discord.Emoji) int { return cmp.Compare(a.ID, b.ID) })
+	slices.SortFunc(stickers, func(a, b discord.Sticker) int { return cmp.Compare(a.ID, b.ID) })
+	slices.SortFunc(scheduledEvents, func(a, b discord.GuildScheduledEvent) int { return cmp.Compare(a.ID, b.ID) })
+
+	guild := &Guild{
+		Version:         Version,
+		ID:              guildID,
+		Name:            restGuild.Name,
+		Roles:           roles,
+		Channels:        channels,
+		Emojis:          emojis,
+		Stickers:        stickers,
+		ScheduledEvents: scheduledEvents,
+		Members:         members,
+	}
+	s.guilds[guildID] = guild
+	return guild, nil
+}
+
+// membersPageSize is the maximum number of members discord returns at once
+const membersPageSize = 1000
+
+// members fetches all the members of a guild, page by page, they are sorted by user ID
+func (s *restSource) members(guildID snowflake.ID) ([]discord.Member, error) {
+	var members []discord.Member
+	var after snowflake.ID
+	for {
+		page, err := s.client.Rest().GetMembers(guildID, membersPageSize, after)
+		if err != nil {
+			return nil, err
+		}
+		members = append(members, page...)
+		if len(page) < membersPageSize {
+			return members, nil
+		}
+		after = page[len(page)-1].User.ID
+	}
+}
+
+// dirSource reads the guilds and commands from the snapshots of a directory
+type dirSource struct {
+	dir string
+}
+
+// NewDirSource returns a source reading the guilds and commands from the snapshots saved in a directory
+func NewDirSource(dir string) Source {
+	return dirSource{dir: dir}
+}
+
+func (s dirSource) Guild(guildID snowflake.ID) (*Guild, error) {
+	return Load(Path(s.dir, guildID))
+}
+
+// Path returns the path of the snapshot of a guild in a directory
+func Path(dir string, guildID snowflake.ID) string {
+	return filepath.Join(dir, guildID.String()+".json")
+}
+
+// Save writes the snapshot of a guild in a directory, the directory is created if needed
+func Save(dir string, guild *Guild) error {
+	data, err := json.MarshalIndent(guild, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode snapshot of guild '%s': %w", guild.ID, err)
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(Path(dir, guild.ID), append(data, '\n'), 0644)
+}
+
+// Load reads a snapshot, it fails if the snapshot was written in another format version
+func Load(path string) (*Guild, error) {
+	guild := new(Guild)
+	if err := load(path, guild); err != nil {
+		return nil, err
+	}
+	return guild, nil
+}
+
+// load reads a snapshot into v, after checking its format version
+func load(path string, v any) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var header struct {
+		Version int `json:"version"`
+	}
+	if err := json.Unmarshal(data, &header); err != nil {
+		return fmt.Errorf("failed to read snapshot '%s': %w", path, err)
+	}
+	if header.Version != Version {
+		return fmt.Errorf("%w: '%s' has version %d, expected %d", ErrUnsupportedVersion, path, header.Version, Version)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("failed to read snapshot '%s': %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,169 @@
+package sdk
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"slices"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/handler"
+	"github.com/disgoorg/snowflake/v2"
+)
+
+// SyncRoles assigns the configured roles to the bot in the guilds to sync
+func (b *Bot) SyncRoles() error {
+	cfg := b.Config()
+	var errs []error
+	for _, guildID := range cfg.Bot.GetGuildsToSync() {
+		guildRoles := cfg.Bot.GetGuildRoles(guildID)
+		slog.Info(fmt.Sprintf("Syncing roles for guild '%s'", guildID), slog.Any("roles", guildRoles))
+
+		for _, roleID := range guildRoles {
+			// Assign each role to the bot
+			if err := b.Client.Rest().AddMemberRole(guildID, b.Client.ApplicationID(), roleID); err != nil {
+				errs = append(errs, fmt.Errorf("failed to assign role '%s' in guild '%s': %w", roleID, guildID, err))
+				continue
+			}
+			slog.Info(fmt.Sprintf("Successfully assigned role '%s' to bot in guild '%s'", roleID, guildID))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// SyncCommands overwrites the commands of the guilds to sync, or the global commands if there are none
+func (b *Bot) SyncCommands(commands []discord.ApplicationCommandCreate) error {
+	guilds := b.Config().Bot.GetGuildsToSync()
+	slog.Info("Syncing commands", slog.Any("guilds", guilds))
+	return handler.SyncCommands(b.Client, commands, guilds)
+}
+
+// CommandsPlan lists the changes a commands sync would make in a guild, by command name
+type CommandsPlan struct {
+	GuildID snowflake.ID
+	Create  []string
+	Update  []string
+	Delete  []string
+}
+
+// IsEmpty returns true if the sync would not change anything
+func (p CommandsPlan) IsEmpty() bool {
+	return len(p.Create) == 0 && len(p.Update) == 0 && len(p.Delete) == 0
+}
+
+// PlanCommands compares the commands with the ones registered on discord, without changing them
+func (b *Bot) PlanCommands(commands []discord.ApplicationCommandCreate) ([]CommandsPlan, error) {
+	var plans []CommandsPlan
+	for _, guildID := range b.Config().Bot.GetGuildsToSync() {
+		remote, err := b.Client.Rest().GetGuildCommands(b.Client.ApplicationID(), guildID, true)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch commands of guild '%s': %w", guildID, err)
+		}
+		plan, err := planCommands(commands, remote)
+		if err != nil {
+			return nil, err
+		}
+		plan.GuildID = guildID
+		plans = append(plans, plan)
+	}
+	return plans, nil
+}
+
+func planCommands(local []discord.ApplicationCommandCreate, remote []discord.ApplicationCommand) (CommandsPlan, error) {
+	var plan CommandsPlan
+	remoteByKey := map[string]discord.ApplicationCommand{}
+	for _, command := range remote {
+		remoteByKey[commandKey(command.Type(), command.Name())] = command
+	}
+
+	seen := map[string]bool{}
+	for _, command := range local {
+		key := commandKey(command.Type(), command.CommandName())
+		seen[key] = true
+		existing, ok := remoteByKey[key]
+		if !ok {
+			plan.Create = append(plan.Create, command.CommandName())
+			continue
+		}
+		changed, err := commandChanged(command, existing)
+		if err != nil {
+			return plan, err
+		}
+		if changed {
+			plan.Update = append(plan.Update, command.CommandName())
+		}
+	}
+	for key, command := range remoteByKey {
+		if !seen[key] {
+			plan.Delete = append(plan.Delete, command.Name())
+		}
+	}
+	slices.Sort(plan.Delete)
+	return plan, nil
+}
+
+func commandKey(t discord.ApplicationCommandType, name string) string {
+	return fmt.Sprintf("%d:%s", t, name)
+}
+
+// commandChanged compares the fields set locally with the registered ones, options included,
+// the fields only known by discord such as IDs and versions are ignored
+func commandChanged(local discord.ApplicationCommandCreate, remote discord.ApplicationCommand) (bool, error) {
+	localFields, err := toFields(local)
+	if err != nil {
+		return false, err
+	}
+	remoteFields, err := toFields(remote)
+	if err != nil {
+		return false, err
+	}
+	return !sameFields(localFields, remoteFields), nil
+}
+
+// sameFields returns true if the fields set locally have the same values remotely, in nested objects too.
+// The fields only set by discord, e.g. IDs or the defaults it sends back such as "autocomplete": false, are ignored
+func sameFields(local any, remote any) bool {
+	switch l := local.(type) {
+	case map[string]any:
+		r, ok := remote.(map[string]any)
+		if !ok {
+			return len(l) == 0 && remote == nil
+		}
+		for name, value := range l {
+			if !sameFields(value, r[name]) {
+				return false
+			}
+		}
+		return true
+	case []any:
+		r, ok := remote.([]any)
+		if !ok {
+			return len(l) == 0 && remote == nil
+		}
+		if len(l) != len(r) {
+			return false
+		}
+		for i := range l {
+			if !sameFields(l[i], r[i]) {
+				return false
+			}
+		}
+		return true
+	default:
+		return reflect.DeepEqual(local, remote)
+	}
+}
+
+func toFields(v any) (map[string]any, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
@@ -0,0 +1,105 @@
+package sdk
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+// remoteTestCommand is a command as discord returns it, with the defaults of its nested options
+const remoteTestCommand = `{
+	"id": "1000",
+	"application_id": "2000",
+	"version": "3000",
+	"type": 1,
+	"name": "test",
+	"name_localizations": null,
+	"description": "Test command",
+	"description_localizations": null,
+	"default_member_permissions": null,
+	"nsfw": false,
+	"options": [{
+		"type": 3,
+		"name": "choice",
+		"name_localizations": null,
+		"description": "Select a number",
+		"description_localizations": null,
+		"required": true,
+		"autocomplete": false
+	}]
+}`
+
+func testCommand(description string) discord.ApplicationCommandCreate {
+	return discord.SlashCommandCreate{
+		Name:        "test",
+		Description: "Test command",
+		Options: []discord.ApplicationCommandOption{
+			discord.ApplicationCommandOptionString{Name: "choice", Description: description, Required: true},
+		},
+	}
+}
+
+func TestPlanCommands(t *testing.T) {
+	var remote discord.UnmarshalApplicationCommand
+	if err := json.Unmarshal([]byte(remoteTestCommand), &remote); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name  string
+		local []discord.ApplicationCommandCreate
+		want  CommandsPlan
+	}{
+		{
+			name:  "unchanged",
+			local: []discord.ApplicationCommandCreate{testCommand("Select a number")},
+		},
+		{
+			name:  "changed option",
+			local: []discord.ApplicationCommandCreate{testCommand("Pick a number")},
+			want:  CommandsPlan{Update: []string{"test"}},
+		},
+		{
+			name:  "created and deleted",
+			local: []discord.ApplicationCommandCreate{discord.SlashCommandCreate{Name: "help", Description: "Help"}},
+			want:  CommandsPlan{Create: []string{"help"}, Delete: []string{"test"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan, err := planCommands(tt.local, []discord.ApplicationCommand{remote.ApplicationCommand})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(plan.Create, tt.want.Create) || !slices.Equal(plan.Update, tt.want.Update) || !slices.Equal(plan.Delete, tt.want.Delete) {
+				t.Errorf("planCommands = %+v, want %+v", plan, tt.want)
+			}
+		})
+	}
+}
+
+func TestSameFields(t *testing.T) {
+	tests := []struct {
+		name   string
+		local  any
+		remote any
+		want   bool
+	}{
+		{"equal values", "a", "a", true},
+		{"different values", "a", "b", false},
+		{"field only set remotely", map[string]any{"a": 1.0}, map[string]any{"a": 1.0, "b": false}, true},
+		{"field missing remotely", map[string]any{"a": 1.0}, map[string]any{}, false},
+		{"nested default", map[string]any{"options": []any{map[string]any{"name": "x"}}}, map[string]any{"options": []any{map[string]any{"name": "x", "autocomplete": false}}}, true},
+		{"nested change", map[string]any{"options": []any{map[string]any{"name": "x"}}}, map[string]any{"options": []any{map[string]any{"name": "y"}}}, false},
+		{"removed option", []any{1.0}, []any{1.0, 2.0}, false},
+		{"empty object sent as null", map[string]any{}, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sameFields(tt.local, tt.remote); got != tt.want {
+				t.Errorf("sameFields(%v, %v) = %v, want %v", tt.local, tt.remote, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// numberedLines returns n lines "line <i>", the changed lines are replaced, or removed if their replacement is empty
+func numberedLines(n int, changed map[int]string) string {
+	var b strings.Builder
+	for i := 1; i <= n; i++ {
+		if line, ok := changed[i]; ok {
+			if line != "" {
+				b.WriteString(line + "\n")
+			}
+			continue
+		}
+		fmt.Fprintf(&b, "line %d\n", i)
+	}
+	return b.String()
+}
+
+// The expected diffs are the ones printed by "diff -u"
+func TestUnifiedDiff(t *testing.T) {
+	tests := []struct {
+		name string
+		old  string
+		new  string
+		want string
+	}{
+		{"equal", "a\nb\n", "a\nb\n", ""},
+		{"empty old file", "", "a\nb\n", "@@ -0,0 +1,2 @@\n+a\n+b\n"},
+		{"empty new file", "a\nb\n", "", "@@ -1,2 +0,0 @@\n-a\n-b\n"},
+		{"single line", "a\n", "b\n", "@@ -1 +1 @@\n-a\n+b\n"},
+		{"missing newline in old file", "a\nb", "a\nb\n", "@@ -1,2 +1,2 @@\n a\n-b\n\\ No newline at end of file\n+b\n"},
+		{"missing newline in new file", "a\nb\n", "a\nc", "@@ -1,2 +1,2 @@\n a\n-b\n+c\n\\ No newline at end of file\n"},
+		{
+			"context",
+			numberedLines(10, nil),
+			numberedLines(10, map[int]string{5: "five"}),
+			"@@ -2,7 +2,7 @@\n line 2\n line 3\n line 4\n-line 5\n+five\n line 6\n line 7\n line 8\n",
+		},
+		{
+			"removed first line",
+			numberedLines(10, nil),
+			numberedLines(10, map[int]string{1: ""}),
+			"@@ -1,4 +1,3 @@\n-line 1\n line 2\n line 3\n line 4\n",
+		},
+		{
+			"close changes share a hunk",
+			numberedLines(20, nil),
+			numberedLines(20, map[int]string{5: "five", 12: "twelve"}),
+			"@@ -2,14 +2,14 @@\n line 2\n line 3\n line 4\n-line 5\n+five\n line 6\n line 7\n line 8\n line 9\n line 10\n line 11\n-line 12\n+twelve\n line 13\n line 14\n line 15\n",
+		},
+		{
+			"distant changes",
+			numberedLines(20, nil),
+			numberedLines(20, map[int]string{2: "two", 19: "nineteen"}),
+			"@@ -1,5 +1,5 @@\n line 1\n-line 2\n+two\n line 3\n line 4\n line 5\n@@ -16,5 +16,5 @@\n line 16\n line 17\n line 18\n-line 19\n+nineteen\n line 20\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := tt.want
+			if want != "" {
+				want = "--- old\n+++ new\n" + want
+			}
+			if got := UnifiedDiff("old", "new", []byte(tt.old), []byte(tt.new)); got != want {
+				t.Errorf("UnifiedDiff =\n%s\nwant\n%s", got, want)
+			}
+		})
+	}
+}
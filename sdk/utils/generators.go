@@ -2,15 +2,21 @@ package utils
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"go/format"
-	"html/template"
+	"go/parser"
+	"go/token"
+	"io"
 	"log/slog"
 	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
+	"text/template"
 
 	"github.com/bil0u/galaxy-os/sdk"
-	"github.com/disgoorg/disgo/bot"
+	"github.com/bil0u/galaxy-os/sdk/snapshot"
 )
 
 const GeneratorDirective = `//go:generate go run $WORKDIR/cmd/ -generator --config-dir=$WORKDIR`
@@ -21,7 +27,7 @@ const GeneratedFileHeader = `
 
 type CodeGenerator interface {
 	Name() string
-	Setup(client bot.Client, cfg sdk.BotConfig)
+	Setup(source snapshot.Source, cfg sdk.BotConfig)
 	Generate() error
 }
 
@@ -31,10 +37,11 @@ func RegisterGenerator(g CodeGenerator) {
 	allGenerators = append(allGenerators, g)
 }
 
-func RunAllGenerators(client bot.Client, cfg sdk.BotConfig) {
+// RunAllGenerators runs the generators, reading the guilds from the given source
+func RunAllGenerators(source snapshot.Source, cfg sdk.BotConfig) {
 	for _, g := range allGenerators {
 		slog.Info(fmt.Sprintf("Running generator '%s'", g.Name()))
-		g.Setup(client, cfg)
+		g.Setup(source, cfg)
 		err := g.Generate()
 		if err != nil {
 			slog.Error(fmt.Sprintf("Failed to run generator '%s'. ", g.Name()), slog.Any("err", err))
@@ -44,16 +51,83 @@ func RunAllGenerators(client bot.Client, cfg sdk.BotConfig) {
 	}
 }
 
-// SourceFileGenerator is a code generator that uses a template to generate code
+// ErrGeneratedDrift is returned when generated files differ from what the generators render
+var ErrGeneratedDrift = errors.New("generated files are out of date")
+
+// FileRenderer is a code generator able to render its file in memory, so that it can be checked for drift
+type FileRenderer interface {
+	CodeGenerator
+	OutputPath() string
+	Render() ([]byte, error)
+}
+
+// CheckAllGenerators renders the files of the generators without writing them, and prints
+// the unified diff of each file differing from the one on disk. It returns ErrGeneratedDrift if any does
+func CheckAllGenerators(source snapshot.Source, cfg sdk.BotConfig, out io.Writer) error {
+	var drifted []string
+	var errs []error
+	for _, g := range allGenerators {
+		r, ok := g.(FileRenderer)
+		if !ok {
+			slog.Warn(fmt.Sprintf("Generator '%s' can't render in memory, skipping it", g.Name()))
+			continue
+		}
+		slog.Info(fmt.Sprintf("Checking generator '%s'", g.Name()))
+		r.Setup(source, cfg)
+		rendered, err := r.Render()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to render '%s': %w", r.Name(), err))
+			continue
+		}
+		current, err := os.ReadFile(r.OutputPath())
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			errs = append(errs, fmt.Errorf("failed to read '%s': %w", r.OutputPath(), err))
+			continue
+		}
+		if diff := UnifiedDiff("a/"+r.OutputPath(), "b/"+r.OutputPath(), current, rendered); diff != "" {
+			fmt.Fprint(out, diff)
+			drifted = append(drifted, r.OutputPath())
+		}
+	}
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+	if len(drifted) > 0 {
+		return fmt.Errorf("%w: %s", ErrGeneratedDrift, strings.Join(drifted, ", "))
+	}
+	return nil
+}
+
+// GoTemplateFuncs render data as Go code in the templates of source file generators, as data read from
+// discord must never be written as is
+var GoTemplateFuncs = template.FuncMap{
+	// quote renders a string literal
+	"quote": strconv.Quote,
+	// ident renders an identifier, it fails if the value is not a valid Go identifier
+	"ident": func(s string) (string, error) {
+		if !token.IsIdentifier(s) {
+			return "", fmt.Errorf("'%s' is not a valid Go identifier", s)
+		}
+		return s, nil
+	},
+	// comment renders text on a single comment line
+	"comment": func(s string) string {
+		return strings.Join(strings.Fields(s), " ")
+	},
+}
+
+// SourceFileGenerator is a code generator that uses a template to generate code,
+// its template must render data with the GoTemplateFuncs, e.g. {{ quote .Name }} for a string literal
 
 type SourceFileGenerator struct {
-	Client        bot.Client
+	// Source provides the guilds to read, from discord or from snapshots
+	Source        snapshot.Source
 	Cfg           sdk.BotConfig
 	OutputFile    string
 	Header        string
 	Template      string
 	TemplateFuncs template.FuncMap
-	GetData       func(g *SourceFileGenerator) any
+	GetData       func(g *SourceFileGenerator) (any, error)
 }
 
 func (g *SourceFileGenerator) Name() string {
@@ -61,38 +135,111 @@ func (g *SourceFileGenerator) Name() string {
 	return filenameParts[len(filenameParts)-1]
 }
 
-func (g *SourceFileGenerator) Setup(client bot.Client, cfg sdk.BotConfig) {
-	g.Client = client
+func (g *SourceFileGenerator) Setup(source snapshot.Source, cfg sdk.BotConfig) {
+	g.Source = source
 	g.Cfg = cfg
 }
 
-func (g *SourceFileGenerator) Generate() error {
+func (g *SourceFileGenerator) OutputPath() string {
+	return g.OutputFile
+}
 
-	// Adding header to the template
-	g.Template = fmt.Sprintf("%s\n%s", g.Header, g.Template)
+// Render returns the formatted source of the file, without writing it
+func (g *SourceFileGenerator) Render() ([]byte, error) {
 
-	// Create a new template and parse the template string
-	tmpl, err := template.New("generator-template").Funcs(g.TemplateFuncs).Parse(g.Template)
+	data, err := g.GetData(g)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	// Create a new template and parse the template string, header included
+	tmpl, err := template.New("generator-template").Funcs(GoTemplateFuncs).Funcs(g.TemplateFuncs).Option("missingkey=error").Parse(fmt.Sprintf("%s\n%s", g.Header, g.Template))
+	if err != nil {
+		return nil, err
 	}
 
 	// Execute the template and append it to the buffer
 	var buf bytes.Buffer
-	if err := tmpl.Execute(&buf, g.GetData(g)); err != nil {
-		return fmt.Errorf("failed to execute template: %w", err)
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("failed to execute template: %w", err)
+	}
+
+	// Make sure the source is valid Go before writing anything
+	if _, err := parser.ParseFile(token.NewFileSet(), g.OutputFile, buf.Bytes(), parser.AllErrors|parser.ParseComments); err != nil {
+		return nil, fmt.Errorf("generated source is invalid: %w", err)
 	}
 
 	// Format the source
 	formatted, err := format.Source(buf.Bytes())
 	if err != nil {
-		return fmt.Errorf("failed to format source: %w", err)
+		return nil, fmt.Errorf("failed to format source: %w", err)
+	}
+	return formatted, nil
+}
+
+func (g *SourceFileGenerator) Generate() error {
+	source, err := g.Render()
+	if err != nil {
+		return err
 	}
 
 	// Write the source to the destination file
-	if err := os.WriteFile(g.OutputFile, formatted, 0644); err != nil {
+	if err := os.WriteFile(g.OutputFile, source, 0644); err != nil {
 		return fmt.Errorf("failed to write source: %w", err)
 	}
 
 	return nil
 }
+
+// TextFileGenerator is a code generator writing a file that isn't Go source, e.g. a report
+type TextFileGenerator struct {
+	// Source provides the guilds to read, from discord or from snapshots
+	Source     snapshot.Source
+	Cfg        sdk.BotConfig
+	OutputFile string
+	GetData    func(g *TextFileGenerator) (any, error)
+	// Write writes the content of the file from the data
+	Write func(w io.Writer, data any) error
+}
+
+func (g *TextFileGenerator) Name() string {
+	return filepath.Base(g.OutputFile)
+}
+
+func (g *TextFileGenerator) Setup(source snapshot.Source, cfg sdk.BotConfig) {
+	g.Source = source
+	g.Cfg = cfg
+}
+
+func (g *TextFileGenerator) OutputPath() string {
+	return g.OutputFile
+}
+
+// Render returns the content of the file, without writing it
+func (g *TextFileGenerator) Render() ([]byte, error) {
+	data, err := g.GetData(g)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := g.Write(&buf, data); err != nil {
+		return nil, fmt.Errorf("failed to write content: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func (g *TextFileGenerator) Generate() error {
+	content, err := g.Render()
+	if err != nil {
+		return err
+	}
+
+	// Write the content to the destination file, its directory is created if needed
+	if err := os.MkdirAll(filepath.Dir(g.OutputFile), 0755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(g.OutputFile, content, 0644); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	return nil
+}
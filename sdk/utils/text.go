@@ -0,0 +1,44 @@
+package utils
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// RemoveDiacritics strips the accents of a string, e.g. "Ingénieur" becomes "Ingenieur"
+func RemoveDiacritics(s string) string {
+	t := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+	s, _, _ = transform.String(t, s)
+	return s
+}
+
+// transliterations are the letters diacritics removal leaves as they are
+var transliterations = map[rune]string{
+	'ß': "ss", 'ẞ': "SS",
+	'æ': "ae", 'Æ': "AE",
+	'œ': "oe", 'Œ': "OE",
+	'ø': "o", 'Ø': "O",
+	'đ': "d", 'Đ': "D",
+	'ð': "d", 'Ð': "D",
+	'ł': "l", 'Ł': "L",
+	'þ': "th", 'Þ': "TH",
+	'ı': "i",
+}
+
+// Transliterate returns the ASCII spelling of the latin letters of a string, e.g. "Œuvre" becomes "OEuvre".
+// Other characters are kept as they are
+func Transliterate(s string) string {
+	var b strings.Builder
+	for _, r := range RemoveDiacritics(s) {
+		if t, ok := transliterations[r]; ok {
+			b.WriteString(t)
+		} else {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}